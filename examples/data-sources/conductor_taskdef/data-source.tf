@@ -0,0 +1,7 @@
+data "conductor_taskdef" "this" {
+  name = "task_name"
+}
+
+output "retry_count" {
+  value = data.conductor_taskdef.this.retry_count
+}
@@ -0,0 +1,9 @@
+data "conductor_taskdefs" "team" {
+  name_prefix    = "team_"
+  owner_email    = "owner@example.com"
+  has_rate_limit = true
+}
+
+output "names" {
+  value = data.conductor_taskdefs.team.names
+}
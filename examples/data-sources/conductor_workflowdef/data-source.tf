@@ -0,0 +1,8 @@
+data "conductor_workflowdef" "latest" {
+  name = "workflow_name"
+}
+
+data "conductor_workflowdef" "v2" {
+  name    = "workflow_name"
+  version = 2
+}
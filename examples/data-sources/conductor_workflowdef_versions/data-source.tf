@@ -0,0 +1,7 @@
+data "conductor_workflowdef_versions" "this" {
+  name = "workflow_name"
+}
+
+output "versions" {
+  value = [for v in data.conductor_workflowdef_versions.this.versions : v.version]
+}
@@ -0,0 +1,29 @@
+resource "conductor_event_handler" "this" {
+  manifest = <<EOF
+  {
+    "name": "name",
+    "event": "conductor:workflow_name:task_ref",
+    "condition": "$.status == 'COMPLETED'",
+    "active": true,
+    "actions": [
+      {
+        "action": "start_workflow",
+        "start_workflow": {
+          "name": "workflow_name",
+          "input": {
+            "id": "$${id}"
+          }
+        }
+      },
+      {
+        "action": "complete_task",
+        "complete_task": {
+          "workflowId": "$${workflowId}",
+          "taskRefName": "task_ref",
+          "output": {}
+        }
+      }
+    ]
+  }
+  EOF
+}
@@ -0,0 +1,5 @@
+# Import the latest version in auto version mode
+terraform import conductor_workflowdef.this my_workflow
+
+# Import a specific version in manual version mode, the manifest keeps the "version" field
+terraform import conductor_workflowdef.this my_workflow:3
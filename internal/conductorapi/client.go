@@ -0,0 +1,67 @@
+package conductorapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Requester sends a single request to the Conductor API, the path is relative to the API endpoint.
+type Requester interface {
+	Do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error)
+}
+
+// Client is a typed client for the Conductor metadata API.
+type Client struct {
+	requester Requester
+}
+
+func NewClient(requester Requester) *Client {
+	return &Client{requester: requester}
+}
+
+// send marshals requestBody (when not nil) as JSON, and decodes a successful response into responseBody (when not nil).
+// Non 2xx responses are returned as *APIError.
+func (c *Client) send(ctx context.Context, method, path string, requestBody interface{}, responseBody interface{}) error {
+	var body io.Reader
+	if requestBody != nil {
+		requestBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			return fmt.Errorf("request body marshal error: %w", err)
+		}
+		body = bytes.NewReader(requestBytes)
+	}
+
+	response, err := c.requester.Do(ctx, method, path, body)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer response.Body.Close()
+
+	bodyBytes, err := io.ReadAll(response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		if err != nil {
+			return newAPIError(method, path, response, fmt.Sprintf("Read All Body Error: %s", err))
+		}
+		return newAPIError(method, path, response, string(bodyBytes))
+	}
+
+	if err != nil {
+		return fmt.Errorf("status was OK but failed to read response body: %w", err)
+	}
+
+	if responseBody == nil || len(bytes.TrimSpace(bodyBytes)) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(bodyBytes, responseBody)
+	if err != nil {
+		return fmt.Errorf("response JSON parse error: %w", err)
+	}
+
+	return nil
+}
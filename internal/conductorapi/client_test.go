@@ -0,0 +1,57 @@
+package conductorapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// testRequester sends the requests to an httptest server the same way the provider http client does.
+type testRequester struct {
+	baseURL string
+}
+
+func (r testRequester) Do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, r.baseURL+"/api/"+path, body)
+	if err != nil {
+		return nil, err
+	}
+	return http.DefaultClient.Do(req)
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewClient(testRequester{baseURL: server.URL})
+}
+
+func TestSendDecodesResponseBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"task","retryCount":3}`))
+	})
+
+	taskDef, err := client.GetTaskDef(context.Background(), "task")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if taskDef.Name != "task" || taskDef.RetryCount != 3 {
+		t.Errorf("task def = %+v, expected name task and retry count 3", taskDef)
+	}
+}
+
+func TestSendEmptyResponseBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	err := client.UpsertWorkflowDef(context.Background(), Manifest{"name": "wf"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
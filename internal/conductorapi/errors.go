@@ -0,0 +1,82 @@
+package conductorapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned for every non 2xx response from Conductor.
+// Message and ValidationErrors are filled when the body is a Conductor structured error.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Status     string
+	Body       string
+
+	Message          string
+	ValidationErrors []ValidationError
+}
+
+// ValidationError is a single field error, Path is the server side path e.g. - registerTaskDef.taskDefinitions[0].name.
+type ValidationError struct {
+	Path         string      `json:"path"`
+	Message      string      `json:"message"`
+	InvalidValue interface{} `json:"invalidValue,omitempty"`
+}
+
+type errorResponse struct {
+	Message          string            `json:"message"`
+	ValidationErrors []ValidationError `json:"validationErrors"`
+}
+
+func newAPIError(method, path string, response *http.Response, body string) *APIError {
+	apiErr := &APIError{
+		Method:     method,
+		Path:       path,
+		StatusCode: response.StatusCode,
+		Status:     response.Status,
+		Body:       body,
+	}
+
+	var errResponse errorResponse
+	if json.Unmarshal([]byte(body), &errResponse) == nil {
+		apiErr.Message = errResponse.Message
+		apiErr.ValidationErrors = errResponse.ValidationErrors
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s %s received non-OK HTTP status: %s. Body: %s", e.Method, e.Path, e.Status, e.Body)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s %s received non-OK HTTP status: %s. %s", e.Method, e.Path, e.Status, e.Message))
+	for _, validationErr := range e.ValidationErrors {
+		builder.WriteString(fmt.Sprintf("\n- %s: %s", validationErr.Path, validationErr.Message))
+	}
+	return builder.String()
+}
+
+// ManifestPath returns the path of the error inside the sent definition.
+// The server path starts with the method and parameter names (e.g. - update.workflowDefs[0].tasks[3].name), those are removed (tasks[3].name).
+// Empty string is returned for errors on the definition root.
+func (v ValidationError) ManifestPath() string {
+	segments := strings.SplitN(v.Path, ".", 3)
+	if len(segments) < 3 {
+		return ""
+	}
+	return segments[2]
+}
+
+// IsNotFound returns true when err is an *APIError with a 404 status.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
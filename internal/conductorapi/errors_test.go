@@ -0,0 +1,97 @@
+package conductorapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorStructuredBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{
+			"status": 400,
+			"message": "Validation failed, check below errors for detail.",
+			"validationErrors": [
+				{"path": "update.workflowDefs[0].tasks[3].name", "message": "name cannot be empty", "invalidValue": ""},
+				{"path": "update.workflowDefs", "message": "workflow def is invalid"}
+			]
+		}`))
+	})
+
+	err := client.UpsertWorkflowDef(context.Background(), Manifest{"name": "wf"})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, expected *APIError", err)
+	}
+
+	if apiErr.Method != http.MethodPut || apiErr.Path != "metadata/workflow" || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("api error = %s %s %d, expected PUT metadata/workflow 400", apiErr.Method, apiErr.Path, apiErr.StatusCode)
+	}
+
+	if apiErr.Message != "Validation failed, check below errors for detail." {
+		t.Errorf("message = %q", apiErr.Message)
+	}
+
+	if len(apiErr.ValidationErrors) != 2 {
+		t.Fatalf("validation errors = %d, expected 2", len(apiErr.ValidationErrors))
+	}
+
+	manifestPaths := []string{apiErr.ValidationErrors[0].ManifestPath(), apiErr.ValidationErrors[1].ManifestPath()}
+	if manifestPaths[0] != "tasks[3].name" || manifestPaths[1] != "" {
+		t.Errorf("manifest paths = %q, expected [tasks[3].name, \"\"]", manifestPaths)
+	}
+
+	if !strings.Contains(err.Error(), "- update.workflowDefs[0].tasks[3].name: name cannot be empty") {
+		t.Errorf("error message %q doesn't list the validation errors", err.Error())
+	}
+}
+
+func TestAPIErrorPlainBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("upstream unavailable"))
+	})
+
+	_, err := client.GetTaskDef(context.Background(), "task")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, expected *APIError", err)
+	}
+
+	if apiErr.Message != "" || apiErr.ValidationErrors != nil || apiErr.Body != "upstream unavailable" {
+		t.Errorf("api error = %+v, expected only the raw body", apiErr)
+	}
+
+	if !strings.Contains(err.Error(), "Body: upstream unavailable") {
+		t.Errorf("error message %q doesn't contain the body", err.Error())
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "not found", err: &APIError{StatusCode: http.StatusNotFound}, expected: true},
+		{name: "wrapped not found", err: fmt.Errorf("read: %w", &APIError{StatusCode: http.StatusNotFound}), expected: true},
+		{name: "server error", err: &APIError{StatusCode: http.StatusInternalServerError}, expected: false},
+		{name: "other error", err: errors.New("boom"), expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := IsNotFound(test.err); actual != test.expected {
+				t.Errorf("IsNotFound(%v) = %t, expected %t", test.err, actual, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package conductorapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GetEventHandlers returns all the event handlers.
+func (c *Client) GetEventHandlers(ctx context.Context) ([]EventHandler, error) {
+	var eventHandlers []EventHandler
+	err := c.send(ctx, http.MethodGet, "event", nil, &eventHandlers)
+	if err != nil {
+		return nil, err
+	}
+	return eventHandlers, nil
+}
+
+// GetEventHandlerManifest returns the event handler with all the fields returned by the server.
+// Conductor has no get by name endpoint, so all the handlers are listed, a 404 *APIError is returned when not found.
+func (c *Client) GetEventHandlerManifest(ctx context.Context, name string) (Manifest, error) {
+	var manifests []Manifest
+	err := c.send(ctx, http.MethodGet, "event", nil, &manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, manifest := range manifests {
+		if manifestName, _ := manifest["name"].(string); manifestName == name {
+			return manifest, nil
+		}
+	}
+
+	return nil, &APIError{
+		Method:     http.MethodGet,
+		Path:       "event",
+		StatusCode: http.StatusNotFound,
+		Status:     fmt.Sprintf("%d %s", http.StatusNotFound, http.StatusText(http.StatusNotFound)),
+		Message:    fmt.Sprintf("EventHandler with name %s not found", name),
+	}
+}
+
+func (c *Client) CreateEventHandler(ctx context.Context, manifest Manifest) error {
+	return c.send(ctx, http.MethodPost, "event", manifest, nil)
+}
+
+func (c *Client) UpdateEventHandler(ctx context.Context, manifest Manifest) error {
+	return c.send(ctx, http.MethodPut, "event", manifest, nil)
+}
+
+func (c *Client) DeleteEventHandler(ctx context.Context, name string) error {
+	return c.send(ctx, http.MethodDelete, fmt.Sprintf("event/%s", url.PathEscape(name)), nil, nil)
+}
@@ -0,0 +1,180 @@
+package conductorapi
+
+import "encoding/json"
+
+// Manifest is a raw Conductor definition, used when all the fields must be kept as is.
+type Manifest = map[string]interface{}
+
+// Auditable fields are set by the server on every definition.
+type Auditable struct {
+	CreateTime int64  `json:"createTime,omitempty"`
+	UpdateTime int64  `json:"updateTime,omitempty"`
+	CreatedBy  string `json:"createdBy,omitempty"`
+	UpdatedBy  string `json:"updatedBy,omitempty"`
+}
+
+type TaskDef struct {
+	Auditable
+
+	Name                        string                 `json:"name"`
+	Description                 string                 `json:"description,omitempty"`
+	RetryCount                  int32                  `json:"retryCount"`
+	TimeoutSeconds              int64                  `json:"timeoutSeconds"`
+	InputKeys                   []string               `json:"inputKeys,omitempty"`
+	OutputKeys                  []string               `json:"outputKeys,omitempty"`
+	TimeoutPolicy               string                 `json:"timeoutPolicy,omitempty"`
+	RetryLogic                  string                 `json:"retryLogic,omitempty"`
+	RetryDelaySeconds           int32                  `json:"retryDelaySeconds"`
+	ResponseTimeoutSeconds      int64                  `json:"responseTimeoutSeconds"`
+	ConcurrentExecLimit         *int32                 `json:"concurrentExecLimit,omitempty"`
+	InputTemplate               map[string]interface{} `json:"inputTemplate,omitempty"`
+	RateLimitPerFrequency       *int32                 `json:"rateLimitPerFrequency,omitempty"`
+	RateLimitFrequencyInSeconds *int32                 `json:"rateLimitFrequencyInSeconds,omitempty"`
+	IsolationGroupId            string                 `json:"isolationGroupId,omitempty"`
+	ExecutionNameSpace          string                 `json:"executionNameSpace,omitempty"`
+	OwnerEmail                  string                 `json:"ownerEmail,omitempty"`
+	PollTimeoutSeconds          *int32                 `json:"pollTimeoutSeconds,omitempty"`
+	BackoffScaleFactor          *int32                 `json:"backoffScaleFactor,omitempty"`
+	EnforceSchema               bool                   `json:"enforceSchema,omitempty"`
+}
+
+type WorkflowDef struct {
+	Auditable
+
+	Name                          string                 `json:"name"`
+	Description                   string                 `json:"description,omitempty"`
+	Version                       int32                  `json:"version"`
+	Tasks                         []WorkflowTask         `json:"tasks"`
+	InputParameters               []string               `json:"inputParameters,omitempty"`
+	OutputParameters              map[string]interface{} `json:"outputParameters,omitempty"`
+	FailureWorkflow               string                 `json:"failureWorkflow,omitempty"`
+	SchemaVersion                 int32                  `json:"schemaVersion,omitempty"`
+	Restartable                   bool                   `json:"restartable"`
+	WorkflowStatusListenerEnabled bool                   `json:"workflowStatusListenerEnabled,omitempty"`
+	OwnerEmail                    string                 `json:"ownerEmail,omitempty"`
+	TimeoutPolicy                 string                 `json:"timeoutPolicy,omitempty"`
+	TimeoutSeconds                int64                  `json:"timeoutSeconds"`
+	Variables                     map[string]interface{} `json:"variables,omitempty"`
+	InputTemplate                 map[string]interface{} `json:"inputTemplate,omitempty"`
+	EnforceSchema                 bool                   `json:"enforceSchema,omitempty"`
+}
+
+type WorkflowTask struct {
+	Name                           string                    `json:"name"`
+	TaskReferenceName              string                    `json:"taskReferenceName"`
+	Description                    string                    `json:"description,omitempty"`
+	InputParameters                map[string]interface{}    `json:"inputParameters,omitempty"`
+	Type                           string                    `json:"type,omitempty"`
+	DynamicTaskNameParam           string                    `json:"dynamicTaskNameParam,omitempty"`
+	CaseValueParam                 string                    `json:"caseValueParam,omitempty"`
+	CaseExpression                 string                    `json:"caseExpression,omitempty"`
+	ScriptExpression               string                    `json:"scriptExpression,omitempty"`
+	DecisionCases                  map[string][]WorkflowTask `json:"decisionCases,omitempty"`
+	DynamicForkTasksParam          string                    `json:"dynamicForkTasksParam,omitempty"`
+	DynamicForkTasksInputParamName string                    `json:"dynamicForkTasksInputParamName,omitempty"`
+	DefaultCase                    []WorkflowTask            `json:"defaultCase,omitempty"`
+	ForkTasks                      [][]WorkflowTask          `json:"forkTasks,omitempty"`
+	StartDelay                     int32                     `json:"startDelay,omitempty"`
+	SubWorkflowParam               *SubWorkflowParams        `json:"subWorkflowParam,omitempty"`
+	JoinOn                         []string                  `json:"joinOn,omitempty"`
+	Sink                           string                    `json:"sink,omitempty"`
+	Optional                       bool                      `json:"optional,omitempty"`
+	TaskDefinition                 *TaskDef                  `json:"taskDefinition,omitempty"`
+	RateLimited                    *bool                     `json:"rateLimited,omitempty"`
+	DefaultExclusiveJoinTask       []string                  `json:"defaultExclusiveJoinTask,omitempty"`
+	AsyncComplete                  bool                      `json:"asyncComplete,omitempty"`
+	LoopCondition                  string                    `json:"loopCondition,omitempty"`
+	LoopOver                       []WorkflowTask            `json:"loopOver,omitempty"`
+	RetryCount                     *int32                    `json:"retryCount,omitempty"`
+	EvaluatorType                  string                    `json:"evaluatorType,omitempty"`
+	Expression                     string                    `json:"expression,omitempty"`
+	Permissive                     bool                      `json:"permissive,omitempty"`
+}
+
+// IsSimple returns true for a task executed by workers of the task definition named after the task,
+// a task without a type is a SIMPLE task.
+func (t WorkflowTask) IsSimple() bool {
+	return t.Type == "" || t.Type == "SIMPLE"
+}
+
+type SubWorkflowParams struct {
+	Name         string            `json:"name,omitempty"`
+	Version      *int32            `json:"version,omitempty"`
+	TaskToDomain map[string]string `json:"taskToDomain,omitempty"`
+	// WorkflowDefinition is either an inline workflow definition object or an expression string.
+	WorkflowDefinition interface{} `json:"workflowDefinition,omitempty"`
+}
+
+type EventHandler struct {
+	Name          string        `json:"name"`
+	Event         string        `json:"event"`
+	Condition     string        `json:"condition,omitempty"`
+	Actions       []EventAction `json:"actions"`
+	Active        bool          `json:"active"`
+	EvaluatorType string        `json:"evaluatorType,omitempty"`
+}
+
+// EventAction types - start_workflow, complete_task, fail_task, terminate_workflow, update_workflow_variables.
+type EventAction struct {
+	Action            string                 `json:"action"`
+	StartWorkflow     map[string]interface{} `json:"start_workflow,omitempty"`
+	CompleteTask      map[string]interface{} `json:"complete_task,omitempty"`
+	FailTask          map[string]interface{} `json:"fail_task,omitempty"`
+	TerminateWorkflow map[string]interface{} `json:"terminate_workflow,omitempty"`
+	UpdateVariables   map[string]interface{} `json:"update_workflow_variables,omitempty"`
+	ExpandInlineJSON  bool                   `json:"expandInlineJSON,omitempty"`
+}
+
+// DecodeManifest converts a raw manifest into a typed definition.
+func DecodeManifest(manifest Manifest, out interface{}) error {
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(manifestBytes, out)
+}
+
+// WalkWorkflowTasks calls fn for every task, including the tasks nested in decision cases, fork branches, loops
+// and inline sub workflow definitions.
+func WalkWorkflowTasks(tasks []WorkflowTask, fn func(task WorkflowTask)) {
+	for _, task := range tasks {
+		fn(task)
+
+		for _, caseTasks := range task.DecisionCases {
+			WalkWorkflowTasks(caseTasks, fn)
+		}
+		WalkWorkflowTasks(task.DefaultCase, fn)
+		for _, forkBranch := range task.ForkTasks {
+			WalkWorkflowTasks(forkBranch, fn)
+		}
+		WalkWorkflowTasks(task.LoopOver, fn)
+
+		if task.SubWorkflowParam != nil {
+			if inlineDef, ok := task.SubWorkflowParam.InlineWorkflowDef(); ok {
+				WalkWorkflowTasks(inlineDef.Tasks, fn)
+			}
+		}
+	}
+}
+
+// InlineWorkflowDef returns the inline workflow definition, false when the sub workflow is referenced by name
+// or the definition is an expression.
+func (p *SubWorkflowParams) InlineWorkflowDef() (*WorkflowDef, bool) {
+	inlineDefMap, ok := p.WorkflowDefinition.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	var inlineDef WorkflowDef
+	if err := DecodeManifest(inlineDefMap, &inlineDef); err != nil {
+		return nil, false
+	}
+	return &inlineDef, true
+}
+
+// WorkflowDefVersion is a WorkflowDefSummary of the names-and-versions endpoint, it has no update time.
+type WorkflowDefVersion struct {
+	Name       string `json:"name"`
+	Version    int32  `json:"version"`
+	CreateTime int64  `json:"createTime,omitempty"`
+}
@@ -0,0 +1,66 @@
+package conductorapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+func taskDefPath(name string) string {
+	return fmt.Sprintf("metadata/taskdefs/%s", url.PathEscape(name))
+}
+
+func (c *Client) GetTaskDef(ctx context.Context, name string) (*TaskDef, error) {
+	var taskDef TaskDef
+	err := c.send(ctx, http.MethodGet, taskDefPath(name), nil, &taskDef)
+	if err != nil {
+		return nil, err
+	}
+	return &taskDef, nil
+}
+
+// GetTaskDefManifest returns the task definition with all the fields returned by the server.
+func (c *Client) GetTaskDefManifest(ctx context.Context, name string) (Manifest, error) {
+	var manifest Manifest
+	err := c.send(ctx, http.MethodGet, taskDefPath(name), nil, &manifest)
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// GetTaskDefManifests returns all the task definitions with all the fields returned by the server.
+func (c *Client) GetTaskDefManifests(ctx context.Context) ([]Manifest, error) {
+	var manifests []Manifest
+	err := c.send(ctx, http.MethodGet, "metadata/taskdefs", nil, &manifests)
+	if err != nil {
+		return nil, err
+	}
+	return manifests, nil
+}
+
+func (c *Client) CreateTaskDefs(ctx context.Context, manifests ...Manifest) error {
+	return c.send(ctx, http.MethodPost, "metadata/taskdefs", manifests, nil)
+}
+
+func (c *Client) UpdateTaskDef(ctx context.Context, manifest Manifest) error {
+	return c.send(ctx, http.MethodPut, "metadata/taskdefs", manifest, nil)
+}
+
+// DeleteTaskDef deletes the task definition.
+// Conductor returns 500 when deleting a missing task definition, in that case a 404 *APIError is returned.
+func (c *Client) DeleteTaskDef(ctx context.Context, name string) error {
+	err := c.send(ctx, http.MethodDelete, taskDefPath(name), nil, nil)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusInternalServerError {
+		_, getErr := c.GetTaskDef(ctx, name)
+		if IsNotFound(getErr) {
+			return getErr
+		}
+	}
+
+	return err
+}
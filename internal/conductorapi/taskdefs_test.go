@@ -0,0 +1,75 @@
+package conductorapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestDeleteTaskDefMissingReturnsNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"status":500,"message":"Cannot remove the task - no such task definition"}`))
+		case http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":404,"message":"No such taskType found by name"}`))
+		}
+	})
+
+	err := client.DeleteTaskDef(context.Background(), "missing")
+	if !IsNotFound(err) {
+		t.Errorf("error = %v, expected a not found *APIError", err)
+	}
+}
+
+func TestDeleteTaskDefServerErrorIsKept(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusInternalServerError)
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"name":"task"}`))
+		}
+	})
+
+	err := client.DeleteTaskDef(context.Background(), "task")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("error = %v, expected the 500 *APIError", err)
+	}
+}
+
+func TestNamesArePathEscaped(t *testing.T) {
+	var requestURIs []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requestURIs = append(requestURIs, r.RequestURI)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	ctx := context.Background()
+	_, _ = client.GetTaskDef(ctx, "my task/v1")
+	_, _ = client.GetWorkflowDefVersion(ctx, "my wf/v1", 2)
+	_ = client.DeleteWorkflowDefVersion(ctx, "my wf/v1", 2)
+	_, _ = client.GetRunningWorkflowIDs(ctx, "my wf/v1", 2)
+
+	expected := []string{
+		"/api/metadata/taskdefs/my%20task%2Fv1",
+		"/api/metadata/workflow/my%20wf%2Fv1?version=2",
+		"/api/metadata/workflow/my%20wf%2Fv1/2",
+		"/api/workflow/running/my%20wf%2Fv1?version=2",
+	}
+
+	if len(requestURIs) != len(expected) {
+		t.Fatalf("requests = %q, expected %q", requestURIs, expected)
+	}
+
+	for i := range expected {
+		if requestURIs[i] != expected[i] {
+			t.Errorf("request %d URI = %q, expected %q", i, requestURIs[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package conductorapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+func workflowDefPath(name string) string {
+	return fmt.Sprintf("metadata/workflow/%s", url.PathEscape(name))
+}
+
+func workflowDefVersionPath(name string, version int32) string {
+	return fmt.Sprintf("metadata/workflow/%s?version=%d", url.PathEscape(name), version)
+}
+
+// GetWorkflowDefs returns all the workflow definitions, every version is returned as a separate definition.
+func (c *Client) GetWorkflowDefs(ctx context.Context) ([]WorkflowDef, error) {
+	var workflowDefs []WorkflowDef
+	err := c.send(ctx, http.MethodGet, "metadata/workflow", nil, &workflowDefs)
+	if err != nil {
+		return nil, err
+	}
+	return workflowDefs, nil
+}
+
+// GetWorkflowDef returns the latest version of the workflow definition.
+func (c *Client) GetWorkflowDef(ctx context.Context, name string) (*WorkflowDef, error) {
+	var workflowDef WorkflowDef
+	err := c.send(ctx, http.MethodGet, workflowDefPath(name), nil, &workflowDef)
+	if err != nil {
+		return nil, err
+	}
+	return &workflowDef, nil
+}
+
+func (c *Client) GetWorkflowDefVersion(ctx context.Context, name string, version int32) (*WorkflowDef, error) {
+	var workflowDef WorkflowDef
+	err := c.send(ctx, http.MethodGet, workflowDefVersionPath(name, version), nil, &workflowDef)
+	if err != nil {
+		return nil, err
+	}
+	return &workflowDef, nil
+}
+
+// GetWorkflowDefManifest returns the latest version of the workflow definition with all the fields returned by the server.
+func (c *Client) GetWorkflowDefManifest(ctx context.Context, name string) (Manifest, error) {
+	var manifest Manifest
+	err := c.send(ctx, http.MethodGet, workflowDefPath(name), nil, &manifest)
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (c *Client) GetWorkflowDefVersionManifest(ctx context.Context, name string, version int32) (Manifest, error) {
+	var manifest Manifest
+	err := c.send(ctx, http.MethodGet, workflowDefVersionPath(name, version), nil, &manifest)
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// UpsertWorkflowDef creates or updates the workflow definition, the version inside the manifest is used as is.
+func (c *Client) UpsertWorkflowDef(ctx context.Context, manifest Manifest) error {
+	return c.send(ctx, http.MethodPut, "metadata/workflow", []Manifest{manifest}, nil)
+}
+
+func (c *Client) DeleteWorkflowDefVersion(ctx context.Context, name string, version int32) error {
+	path := fmt.Sprintf("metadata/workflow/%s/%d", url.PathEscape(name), version)
+	return c.send(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// GetWorkflowDefVersions returns all the versions of the workflow definition sorted by version, empty when the workflow doesn't exist.
+func (c *Client) GetWorkflowDefVersions(ctx context.Context, name string) ([]WorkflowDefVersion, error) {
+	var namesAndVersions map[string][]WorkflowDefVersion
+	err := c.send(ctx, http.MethodGet, "metadata/workflow/names-and-versions", nil, &namesAndVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := namesAndVersions[name]
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	return versions, nil
+}
@@ -0,0 +1,52 @@
+package conductorapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetWorkflowDefVersions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/metadata/workflow/names-and-versions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"wf": [
+				{"name": "wf", "version": 3, "createTime": 300},
+				{"name": "wf", "version": 1, "createTime": 100},
+				{"name": "wf", "version": 2, "createTime": 200}
+			],
+			"other": [
+				{"name": "other", "version": 1}
+			]
+		}`))
+	})
+
+	versions, err := client.GetWorkflowDefVersions(context.Background(), "wf")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(versions) != 3 {
+		t.Fatalf("versions = %+v, expected the 3 versions of wf only", versions)
+	}
+
+	for i, version := range versions {
+		expectedVersion := int32(i + 1)
+		if version.Name != "wf" || version.Version != expectedVersion || version.CreateTime != int64(expectedVersion)*100 {
+			t.Errorf("versions[%d] = %+v, expected wf version %d", i, version, expectedVersion)
+		}
+	}
+
+	missing, err := client.GetWorkflowDefVersions(context.Background(), "missing")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(missing) != 0 {
+		t.Errorf("versions = %+v, expected no versions for a missing workflow", missing)
+	}
+}
@@ -0,0 +1,20 @@
+package conductorapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GetRunningWorkflowIDs returns the ids of the RUNNING executions of the workflow definition version.
+func (c *Client) GetRunningWorkflowIDs(ctx context.Context, name string, version int32) ([]string, error) {
+	path := fmt.Sprintf("workflow/running/%s?version=%d", url.PathEscape(name), version)
+
+	var workflowIDs []string
+	err := c.send(ctx, http.MethodGet, path, nil, &workflowIDs)
+	if err != nil {
+		return nil, err
+	}
+	return workflowIDs, nil
+}
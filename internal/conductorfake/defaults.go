@@ -0,0 +1,119 @@
+package conductorfake
+
+// Values filled by the server when missing from a definition.
+var defaultTaskDefValues = map[string]interface{}{
+	"description":                 "",
+	"retryCount":                  float64(3),
+	"timeoutSeconds":              float64(0),
+	"inputKeys":                   []interface{}{},
+	"outputKeys":                  []interface{}{},
+	"timeoutPolicy":               "TIME_OUT_WF",
+	"retryLogic":                  "FIXED",
+	"retryDelaySeconds":           float64(60),
+	"responseTimeoutSeconds":      float64(3600),
+	"inputTemplate":               map[string]interface{}{},
+	"rateLimitPerFrequency":       float64(0),
+	"rateLimitFrequencyInSeconds": float64(1),
+	"backoffScaleFactor":          float64(1),
+	"enforceSchema":               false,
+}
+
+var defaultWorkflowDefValues = map[string]interface{}{
+	"description":                   "",
+	"inputParameters":               []interface{}{},
+	"outputParameters":              map[string]interface{}{},
+	"schemaVersion":                 float64(2),
+	"restartable":                   true,
+	"workflowStatusListenerEnabled": false,
+	"timeoutPolicy":                 "ALERT_ONLY",
+	"timeoutSeconds":                float64(0),
+	"variables":                     map[string]interface{}{},
+	"inputTemplate":                 map[string]interface{}{},
+	"enforceSchema":                 true,
+}
+
+var defaultWorkflowTaskValues = map[string]interface{}{
+	"inputParameters":          map[string]interface{}{},
+	"type":                     "SIMPLE",
+	"decisionCases":            map[string]interface{}{},
+	"defaultCase":              []interface{}{},
+	"forkTasks":                []interface{}{},
+	"startDelay":               float64(0),
+	"joinOn":                   []interface{}{},
+	"optional":                 false,
+	"defaultExclusiveJoinTask": []interface{}{},
+	"asyncComplete":            false,
+	"loopOver":                 []interface{}{},
+	"onStateChange":            map[string]interface{}{},
+	"permissive":               false,
+}
+
+func fillDefaults(def map[string]interface{}, defaults map[string]interface{}) {
+	for key, value := range defaults {
+		if _, exists := def[key]; !exists {
+			def[key] = copyValue(value)
+		}
+	}
+}
+
+func fillWorkflowDefDefaults(workflowDef map[string]interface{}) {
+	fillDefaults(workflowDef, defaultWorkflowDefValues)
+
+	tasks, _ := workflowDef["tasks"].([]interface{})
+	fillWorkflowTasksDefaults(tasks)
+}
+
+// fillWorkflowTasksDefaults fills the defaults of the tasks and all their nested tasks.
+func fillWorkflowTasksDefaults(tasks []interface{}) {
+	for _, taskVal := range tasks {
+		task, ok := taskVal.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		fillDefaults(task, defaultWorkflowTaskValues)
+
+		if decisionCases, ok := task["decisionCases"].(map[string]interface{}); ok {
+			for _, caseTasks := range decisionCases {
+				caseTasksArr, _ := caseTasks.([]interface{})
+				fillWorkflowTasksDefaults(caseTasksArr)
+			}
+		}
+
+		defaultCase, _ := task["defaultCase"].([]interface{})
+		fillWorkflowTasksDefaults(defaultCase)
+
+		loopOver, _ := task["loopOver"].([]interface{})
+		fillWorkflowTasksDefaults(loopOver)
+
+		forkTasks, _ := task["forkTasks"].([]interface{})
+		for _, forkBranch := range forkTasks {
+			forkBranchArr, _ := forkBranch.([]interface{})
+			fillWorkflowTasksDefaults(forkBranchArr)
+		}
+
+		if subWorkflowParam, ok := task["subWorkflowParam"].(map[string]interface{}); ok {
+			if inlineDef, ok := subWorkflowParam["workflowDefinition"].(map[string]interface{}); ok {
+				fillWorkflowDefDefaults(inlineDef)
+			}
+		}
+	}
+}
+
+func copyValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			copied[key] = copyValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(typed))
+		for i, item := range typed {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	}
+	return value
+}
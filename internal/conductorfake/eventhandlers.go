@@ -0,0 +1,100 @@
+package conductorfake
+
+import (
+	"fmt"
+	"net/http"
+)
+
+var defaultEventHandlerValues = map[string]interface{}{
+	"active": false,
+}
+
+var defaultEventActionValues = map[string]interface{}{
+	"expandInlineJSON": false,
+}
+
+func (s *Server) serveEventHandlers(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		eventHandlers := make([]map[string]interface{}, 0, len(s.eventHandlers))
+		for _, name := range sortedKeys(s.eventHandlers) {
+			eventHandlers = append(eventHandlers, s.eventHandlers[name])
+		}
+		writeJSON(w, eventHandlers)
+
+	case len(segments) == 0 && (r.Method == http.MethodPost || r.Method == http.MethodPut):
+		var eventHandler map[string]interface{}
+		if !readJSON(w, r, &eventHandler) {
+			return
+		}
+
+		validationPrefix := "addEventHandler.eventHandler"
+		if r.Method == http.MethodPut {
+			validationPrefix = "updateEventHandler.eventHandler"
+		}
+
+		if !validateEventHandler(w, validationPrefix, eventHandler) {
+			return
+		}
+
+		name, ok := eventHandler["name"].(string)
+		if !ok {
+			writeError(w, http.StatusInternalServerError, "event handler name is not a string")
+			return
+		}
+
+		_, exists := s.eventHandlers[name]
+		if r.Method == http.MethodPost && exists {
+			writeError(w, http.StatusConflict, fmt.Sprintf("EventHandler with name %s already exists!", name))
+			return
+		}
+		if r.Method == http.MethodPut && !exists {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("EventHandler with name %s not found!", name))
+			return
+		}
+
+		fillDefaults(eventHandler, defaultEventHandlerValues)
+		actions, _ := eventHandler["actions"].([]interface{})
+		for _, actionVal := range actions {
+			if action, ok := actionVal.(map[string]interface{}); ok {
+				fillDefaults(action, defaultEventActionValues)
+			}
+		}
+
+		s.eventHandlers[name] = eventHandler
+		w.WriteHeader(http.StatusOK)
+
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		if _, exists := s.eventHandlers[segments[0]]; !exists {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("EventHandler with name %s not found!", segments[0]))
+			return
+		}
+		delete(s.eventHandlers, segments[0])
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", r.Method))
+	}
+}
+
+func validateEventHandler(w http.ResponseWriter, validationPath string, eventHandler map[string]interface{}) bool {
+	name, _ := eventHandler["name"].(string)
+	if name == "" {
+		writeValidationError(w, validationPath+".name", "Missing event handler name")
+		return false
+	}
+
+	event, _ := eventHandler["event"].(string)
+	if event == "" {
+		writeValidationError(w, validationPath+".event", "Missing event location")
+		return false
+	}
+
+	actions, _ := eventHandler["actions"].([]interface{})
+	if len(actions) == 0 {
+		writeValidationError(w, validationPath+".actions", "No actions specified. Please specify at-least one action")
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,450 @@
+package conductorfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is an in-memory fake of the Conductor metadata API, plus the running workflows lookup.
+// It mimics the server behaviors the provider relies on: default values filling, auditable fields,
+// version handling and the 500 status returned when deleting a missing task definition.
+type Server struct {
+	// User is set as createdBy / updatedBy, empty means no user like an OSS server without auth.
+	User string
+	// Now returns the time used for createTime / updateTime.
+	Now func() time.Time
+
+	lock         sync.Mutex
+	taskDefs     map[string]map[string]interface{}
+	workflowDefs map[string]map[int32]map[string]interface{}
+
+	eventHandlers map[string]map[string]interface{}
+
+	runningWorkflows map[string]map[int32][]string
+}
+
+func New() *Server {
+	return &Server{
+		Now:          time.Now,
+		taskDefs:     make(map[string]map[string]interface{}),
+		workflowDefs: make(map[string]map[int32]map[string]interface{}),
+
+		eventHandlers: make(map[string]map[string]interface{}),
+
+		runningWorkflows: make(map[string]map[int32][]string),
+	}
+}
+
+// Start starts an http test server, the provider endpoint is the returned server URL + "/api".
+func (s *Server) Start() *httptest.Server {
+	return httptest.NewServer(s)
+}
+
+// AddWorkflowDef stores a workflow definition version like the API does, e.g. to seed versions created outside of terraform.
+// It returns false when the workflow definition has no name.
+func (s *Server) AddWorkflowDef(workflowDef map[string]interface{}) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	name, ok := workflowDef["name"].(string)
+	if !ok || name == "" {
+		return false
+	}
+
+	s.putWorkflowDef(name, workflowDef)
+	return true
+}
+
+// EditTaskDef changes a stored task definition outside of the API, e.g. to simulate drift.
+// It returns false when the task definition does not exist.
+func (s *Server) EditTaskDef(name string, edit func(taskDef map[string]interface{})) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	taskDef, exists := s.taskDefs[name]
+	if !exists {
+		return false
+	}
+
+	edit(taskDef)
+	return true
+}
+
+// EditWorkflowDef changes a stored workflow definition version outside of the API, e.g. to simulate drift.
+// It returns false when the workflow definition version does not exist.
+func (s *Server) EditWorkflowDef(name string, version int32, edit func(workflowDef map[string]interface{})) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	workflowDef, exists := s.workflowDefs[name][version]
+	if !exists {
+		return false
+	}
+
+	edit(workflowDef)
+	return true
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path, ok := strings.CutPrefix(r.URL.Path, "/api/")
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown path %s", r.URL.Path))
+		return
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case len(segments) >= 2 && segments[0] == "metadata" && segments[1] == "taskdefs":
+		s.serveTaskDefs(w, r, segments[2:])
+	case len(segments) >= 2 && segments[0] == "metadata" && segments[1] == "workflow":
+		s.serveWorkflowDefs(w, r, segments[2:])
+	case segments[0] == "event":
+		s.serveEventHandlers(w, r, segments[1:])
+	case segments[0] == "workflow":
+		s.serveWorkflows(w, r, segments[1:])
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown path %s", r.URL.Path))
+	}
+}
+
+func (s *Server) serveTaskDefs(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		names := sortedKeys(s.taskDefs)
+		taskDefs := make([]map[string]interface{}, 0, len(names))
+		for _, name := range names {
+			taskDefs = append(taskDefs, s.taskDefs[name])
+		}
+		writeJSON(w, taskDefs)
+
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var taskDefs []map[string]interface{}
+		if !readJSON(w, r, &taskDefs) {
+			return
+		}
+
+		for i, taskDef := range taskDefs {
+			name, _ := taskDef["name"].(string)
+			if name == "" {
+				writeValidationError(w, fmt.Sprintf("registerTaskDef.taskDefinitions[%d].name", i), "TaskDef name cannot be null or empty")
+				return
+			}
+		}
+
+		for _, taskDef := range taskDefs {
+			name, ok := taskDef["name"].(string)
+			if !ok {
+				writeError(w, http.StatusInternalServerError, "task def name is not a string")
+				return
+			}
+			fillDefaults(taskDef, defaultTaskDefValues)
+			s.setCreateAudit(taskDef)
+			s.taskDefs[name] = taskDef
+		}
+		w.WriteHeader(http.StatusOK)
+
+	case len(segments) == 0 && r.Method == http.MethodPut:
+		var taskDef map[string]interface{}
+		if !readJSON(w, r, &taskDef) {
+			return
+		}
+
+		name, _ := taskDef["name"].(string)
+		if name == "" {
+			writeValidationError(w, "updateTaskDef.taskDef.name", "TaskDef name cannot be null or empty")
+			return
+		}
+
+		existing, exists := s.taskDefs[name]
+		if !exists {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("No such taskType found by name: %s", name))
+			return
+		}
+
+		fillDefaults(taskDef, defaultTaskDefValues)
+		copyCreateAudit(existing, taskDef)
+		s.setUpdateAudit(taskDef)
+		s.taskDefs[name] = taskDef
+		w.WriteHeader(http.StatusOK)
+
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		taskDef, exists := s.taskDefs[segments[0]]
+		if !exists {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("No such taskType found by name: %s", segments[0]))
+			return
+		}
+		writeJSON(w, taskDef)
+
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		if _, exists := s.taskDefs[segments[0]]; !exists {
+			//the real server fails with 500 instead of 404
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("Cannot remove the task - no such task definition: %s", segments[0]))
+			return
+		}
+		delete(s.taskDefs, segments[0])
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", r.Method))
+	}
+}
+
+func (s *Server) serveWorkflowDefs(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		workflowDefs := make([]map[string]interface{}, 0)
+		for _, name := range sortedKeys(s.workflowDefs) {
+			for _, version := range s.sortedVersions(name) {
+				workflowDefs = append(workflowDefs, s.workflowDefs[name][version])
+			}
+		}
+		writeJSON(w, workflowDefs)
+
+	case len(segments) == 0 && r.Method == http.MethodPut:
+		var workflowDefs []map[string]interface{}
+		if !readJSON(w, r, &workflowDefs) {
+			return
+		}
+
+		for i, workflowDef := range workflowDefs {
+			if !validateWorkflowDef(w, fmt.Sprintf("update.workflowDefs[%d]", i), workflowDef) {
+				return
+			}
+		}
+
+		for _, workflowDef := range workflowDefs {
+			name, ok := workflowDef["name"].(string)
+			if !ok {
+				writeError(w, http.StatusInternalServerError, "workflow def name is not a string")
+				return
+			}
+			s.putWorkflowDef(name, workflowDef)
+		}
+		w.WriteHeader(http.StatusOK)
+
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var workflowDef map[string]interface{}
+		if !readJSON(w, r, &workflowDef) {
+			return
+		}
+
+		if !validateWorkflowDef(w, "create.workflowDef", workflowDef) {
+			return
+		}
+
+		name, ok := workflowDef["name"].(string)
+		if !ok {
+			writeError(w, http.StatusInternalServerError, "workflow def name is not a string")
+			return
+		}
+
+		version := workflowDefVersion(workflowDef)
+		if _, exists := s.workflowDefs[name][version]; exists {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Workflow with %s.%d already exists!", name, version))
+			return
+		}
+
+		s.putWorkflowDef(name, workflowDef)
+		w.WriteHeader(http.StatusOK)
+
+	case len(segments) == 1 && segments[0] == "names-and-versions" && r.Method == http.MethodGet:
+		namesAndVersions := make(map[string][]map[string]interface{})
+		for _, name := range sortedKeys(s.workflowDefs) {
+			for _, version := range s.sortedVersions(name) {
+				workflowDef := s.workflowDefs[name][version]
+				namesAndVersions[name] = append(namesAndVersions[name], map[string]interface{}{
+					"name":       name,
+					"version":    version,
+					"createTime": workflowDef["createTime"],
+				})
+			}
+		}
+		writeJSON(w, namesAndVersions)
+
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		name := segments[0]
+		versions := s.sortedVersions(name)
+		if len(versions) == 0 {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("No such workflow found by name: %s", name))
+			return
+		}
+
+		version := versions[len(versions)-1]
+		if versionStr := r.URL.Query().Get("version"); versionStr != "" {
+			parsed, err := strconv.ParseInt(versionStr, 10, 32)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid version: %s", versionStr))
+				return
+			}
+			version = int32(parsed)
+		}
+
+		workflowDef, exists := s.workflowDefs[name][version]
+		if !exists {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("No such workflow found by name: %s, version: %d", name, version))
+			return
+		}
+		writeJSON(w, workflowDef)
+
+	case len(segments) == 2 && r.Method == http.MethodDelete:
+		name := segments[0]
+		version, err := strconv.ParseInt(segments[1], 10, 32)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid version: %s", segments[1]))
+			return
+		}
+
+		if _, exists := s.workflowDefs[name][int32(version)]; !exists {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("No such workflow definition: %s version: %d", name, version))
+			return
+		}
+
+		delete(s.workflowDefs[name], int32(version))
+		if len(s.workflowDefs[name]) == 0 {
+			delete(s.workflowDefs, name)
+		}
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", r.Method))
+	}
+}
+
+func (s *Server) putWorkflowDef(name string, workflowDef map[string]interface{}) {
+	version := workflowDefVersion(workflowDef)
+	workflowDef["version"] = version
+
+	fillWorkflowDefDefaults(workflowDef)
+
+	if s.workflowDefs[name] == nil {
+		s.workflowDefs[name] = make(map[int32]map[string]interface{})
+	}
+
+	if existing, exists := s.workflowDefs[name][version]; exists {
+		copyCreateAudit(existing, workflowDef)
+		s.setUpdateAudit(workflowDef)
+	} else {
+		s.setCreateAudit(workflowDef)
+	}
+
+	s.workflowDefs[name][version] = workflowDef
+}
+
+func (s *Server) sortedVersions(name string) []int32 {
+	versions := make([]int32, 0, len(s.workflowDefs[name]))
+	for version := range s.workflowDefs[name] {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+func (s *Server) setCreateAudit(def map[string]interface{}) {
+	def["createTime"] = s.Now().UnixMilli()
+	delete(def, "updateTime")
+	delete(def, "updatedBy")
+	if s.User != "" {
+		def["createdBy"] = s.User
+	} else {
+		delete(def, "createdBy")
+	}
+}
+
+func (s *Server) setUpdateAudit(def map[string]interface{}) {
+	def["updateTime"] = s.Now().UnixMilli()
+	if s.User != "" {
+		def["updatedBy"] = s.User
+	}
+}
+
+func copyCreateAudit(from map[string]interface{}, to map[string]interface{}) {
+	for _, key := range []string{"createTime", "createdBy"} {
+		if value, exists := from[key]; exists {
+			to[key] = value
+		} else {
+			delete(to, key)
+		}
+	}
+}
+
+func validateWorkflowDef(w http.ResponseWriter, validationPath string, workflowDef map[string]interface{}) bool {
+	name, _ := workflowDef["name"].(string)
+	if name == "" {
+		writeValidationError(w, validationPath+".name", "WorkflowDef name cannot be null or empty")
+		return false
+	}
+
+	tasks, _ := workflowDef["tasks"].([]interface{})
+	if len(tasks) == 0 {
+		writeValidationError(w, validationPath+".tasks", "WorkflowTask list cannot be empty")
+		return false
+	}
+
+	return true
+}
+
+func workflowDefVersion(workflowDef map[string]interface{}) int32 {
+	version, ok := workflowDef["version"].(float64)
+	if !ok || version < 1 {
+		return 1
+	}
+	return int32(version)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, out interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(out)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":    status,
+		"message":   message,
+		"retryable": false,
+	})
+}
+
+func writeValidationError(w http.ResponseWriter, path string, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":    http.StatusBadRequest,
+		"message":   "Validation failed, check below errors for detail.",
+		"retryable": false,
+		"validationErrors": []map[string]interface{}{
+			{"path": path, "message": message},
+		},
+	})
+}
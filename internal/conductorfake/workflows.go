@@ -0,0 +1,44 @@
+package conductorfake
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// SetRunningWorkflows sets the ids of the RUNNING executions of a workflow definition version.
+func (s *Server) SetRunningWorkflows(name string, version int32, workflowIDs ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.runningWorkflows[name] == nil {
+		s.runningWorkflows[name] = make(map[int32][]string)
+	}
+	s.runningWorkflows[name][version] = workflowIDs
+}
+
+func (s *Server) serveWorkflows(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 2 && segments[0] == "running" && r.Method == http.MethodGet:
+		name := segments[1]
+
+		version := int32(1)
+		if versionStr := r.URL.Query().Get("version"); versionStr != "" {
+			parsed, err := strconv.ParseInt(versionStr, 10, 32)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid version: %s", versionStr))
+				return
+			}
+			version = int32(parsed)
+		}
+
+		workflowIDs := s.runningWorkflows[name][version]
+		if workflowIDs == nil {
+			workflowIDs = []string{}
+		}
+		writeJSON(w, workflowIDs)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", r.Method))
+	}
+}
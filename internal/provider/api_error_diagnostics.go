@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// addManifestErrorDiagnostics adds a diagnostic per Conductor validation error pointing into the 'manifest' attribute.
+// Other errors are added as a single error with the given summary.
+func addManifestErrorDiagnostics(diagnostics *diag.Diagnostics, summary string, err error) {
+	var apiErr *conductorapi.APIError
+	if !errors.As(err, &apiErr) || (apiErr.Message == "" && len(apiErr.ValidationErrors) == 0) {
+		diagnostics.AddError(summary, err.Error())
+		return
+	}
+
+	if len(apiErr.ValidationErrors) == 0 {
+		diagnostics.AddAttributeError(path.Root("manifest"), summary, fmt.Sprintf("%s: %s", apiErr.Status, apiErr.Message))
+		return
+	}
+
+	for _, validationErr := range apiErr.ValidationErrors {
+		manifestPath := validationErr.ManifestPath()
+		if manifestPath == "" {
+			manifestPath = "(root)"
+		}
+
+		diagnostics.AddAttributeError(path.Root("manifest"), fmt.Sprintf("%s: invalid manifest value at %s", summary, manifestPath),
+			validationErr.Message)
+	}
+}
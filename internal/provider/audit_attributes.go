@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// auditModel holds the Conductor audit metadata, it is refreshed by Read and kept from the state on plan,
+// unless the manifest changes, then they are unknown until the server response.
+type auditModel struct {
+	CreateTime tftypes.Int64  `tfsdk:"create_time"`
+	UpdateTime tftypes.Int64  `tfsdk:"update_time"`
+	CreatedBy  tftypes.String `tfsdk:"created_by"`
+	UpdatedBy  tftypes.String `tfsdk:"updated_by"`
+}
+
+func auditTimeAttribute(description string) tfschema.Int64Attribute {
+	return tfschema.Int64Attribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func auditUserAttribute(description string) tfschema.StringAttribute {
+	return tfschema.StringAttribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// newAuditModel extracts the audit metadata from a server manifest.
+func newAuditModel(currentManifestMap map[string]interface{}, diagnostics *diag.Diagnostics) auditModel {
+	var auditable conductorapi.Auditable
+	err := conductorapi.DecodeManifest(currentManifestMap, &auditable)
+	if err != nil {
+		diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Unexpected audit fields in manifest: %s", err))
+		return auditModel{
+			CreateTime: tftypes.Int64Null(),
+			UpdateTime: tftypes.Int64Null(),
+			CreatedBy:  tftypes.StringNull(),
+			UpdatedBy:  tftypes.StringNull(),
+		}
+	}
+
+	return auditModel{
+		CreateTime: epochMillisValue(auditable.CreateTime),
+		UpdateTime: epochMillisValue(auditable.UpdateTime),
+		CreatedBy:  nonEmptyStringValue(auditable.CreatedBy),
+		UpdatedBy:  nonEmptyStringValue(auditable.UpdatedBy),
+	}
+}
+
+func epochMillisValue(value int64) tftypes.Int64 {
+	if value == 0 {
+		return tftypes.Int64Null()
+	}
+	return tftypes.Int64Value(value)
+}
+
+// setAuditUnknown marks the audit attributes as unknown in the plan of a manifest change, the server sets them on update
+// (a new workflow def version also has a new create time), UseStateForUnknown would otherwise plan the previous values.
+func setAuditUnknown(ctx context.Context, plan *tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Append(plan.SetAttribute(ctx, path.Root("create_time"), tftypes.Int64Unknown())...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("update_time"), tftypes.Int64Unknown())...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("created_by"), tftypes.StringUnknown())...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("updated_by"), tftypes.StringUnknown())...)
+	return diags
+}
+
+func nonEmptyStringValue(value string) tftypes.String {
+	if value == "" {
+		return tftypes.StringNull()
+	}
+	return tftypes.StringValue(value)
+}
@@ -1,26 +1,82 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
+	"net"
 	"net/http"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+const authorizationHeader = "X-Authorization"
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryMinWait = 1 * time.Second
+	defaultRetryMaxWait = 30 * time.Second
+
+	defaultRequestTimeout = 60 * time.Second
+)
+
 type conductorHttpClient struct {
 	httpClient *http.Client
 	endpoint   string
 	headers    map[string]string
+
+	authKey    string
+	authSecret string
+	tokenLock  sync.Mutex
+	token      string
+
+	maxRetries   int
+	retryMinWait time.Duration
+	retryMaxWait time.Duration
+
+	sensitiveFieldRegexes []*regexp.Regexp
+}
+
+// tokenRefreshError is returned when the auth token can't be refreshed, it is never retried.
+type tokenRefreshError struct {
+	err error
+}
+
+func (e *tokenRefreshError) Error() string {
+	return fmt.Sprintf("auth token refresh failed: %s", e.err)
 }
 
-func (client *conductorHttpClient) createRequest(method, path string, body io.Reader) (*http.Request, error) {
+func (e *tokenRefreshError) Unwrap() error {
+	return e.err
+}
+
+type conductorTokenRequest struct {
+	KeyId     string `json:"keyId"`
+	KeySecret string `json:"keySecret"`
+}
+
+type conductorTokenResponse struct {
+	Token string `json:"token"`
+}
+
+func (client *conductorHttpClient) createRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
 	url := fmt.Sprintf("%s/%s", client.endpoint, path)
 
-	req, err := http.NewRequest(method, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -30,17 +86,77 @@ func (client *conductorHttpClient) createRequest(method, path string, body io.Re
 	}
 	req.Header.Add("Content-Type", "application/json")
 
+	if token := client.currentToken(); token != "" {
+		req.Header.Set(authorizationHeader, token)
+	}
+
 	return req, nil
 }
 
-func createConductorHttpClient(ctx context.Context, data ConductorProviderModel) *conductorHttpClient {
+func createConductorHttpClient(ctx context.Context, data ConductorProviderModel) (*conductorHttpClient, error) {
 	endpointStr := data.Endpoint.ValueString()
 	endpointStr = strings.TrimSuffix(endpointStr, "/")
 
+	transport, err := createHttpTransport(data)
+	if err != nil {
+		return nil, err
+	}
+
+	requestTimeout := defaultRequestTimeout
+	if !data.RequestTimeout.IsNull() {
+		requestTimeout, err = time.ParseDuration(data.RequestTimeout.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("invalid request_timeout: %w", err)
+		}
+	}
+
 	conductorClient := conductorHttpClient{
-		httpClient: http.DefaultClient,
+		httpClient: &http.Client{Transport: transport, Timeout: requestTimeout},
 		endpoint:   endpointStr,
 		headers:    make(map[string]string),
+		authKey:    data.AuthKey.ValueString(),
+		authSecret: data.AuthSecret.ValueString(),
+
+		maxRetries:   defaultMaxRetries,
+		retryMinWait: defaultRetryMinWait,
+		retryMaxWait: defaultRetryMaxWait,
+	}
+
+	if !data.LogSensitiveFields.IsNull() {
+		var sensitiveFields []string
+		for _, value := range data.LogSensitiveFields.Elements() {
+			stringVal, ok := value.(tftypes.String)
+			if ok && !stringVal.IsNull() && !stringVal.IsUnknown() {
+				sensitiveFields = append(sensitiveFields, stringVal.ValueString())
+			}
+		}
+		conductorClient.sensitiveFieldRegexes = sensitiveFieldsRegexes(sensitiveFields)
+	}
+
+	if !data.MaxRetries.IsNull() {
+		if data.MaxRetries.ValueInt64() < 0 {
+			return nil, fmt.Errorf("max_retries can't be negative")
+		}
+		conductorClient.maxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	if !data.RetryMinWait.IsNull() {
+		conductorClient.retryMinWait, err = time.ParseDuration(data.RetryMinWait.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry_min_wait: %w", err)
+		}
+	}
+
+	if !data.RetryMaxWait.IsNull() {
+		conductorClient.retryMaxWait, err = time.ParseDuration(data.RetryMaxWait.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry_max_wait: %w", err)
+		}
+	}
+
+	if conductorClient.retryMinWait > conductorClient.retryMaxWait {
+		return nil, fmt.Errorf("retry_min_wait (%s) can't be larger than retry_max_wait (%s)",
+			conductorClient.retryMinWait, conductorClient.retryMaxWait)
 	}
 
 	if !data.CustomHeaders.IsNull() {
@@ -55,19 +171,319 @@ func createConductorHttpClient(ctx context.Context, data ConductorProviderModel)
 		}
 	}
 
+	if conductorClient.hasAuthKey() {
+		err = conductorClient.refreshToken(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Conductor Http Client with endpoint: %s created", conductorClient.endpoint))
-	return &conductorClient
+	return &conductorClient, nil
 }
 
-func (client *conductorHttpClient) sendRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+func createHttpTransport(data ConductorProviderModel) (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+	}
+
+	if !data.CaCertPem.IsNull() || !data.CaCertFile.IsNull() {
+		certPool, err := x509.SystemCertPool()
+		if err != nil {
+			certPool = x509.NewCertPool()
+		}
+
+		if !data.CaCertPem.IsNull() {
+			if !certPool.AppendCertsFromPEM([]byte(data.CaCertPem.ValueString())) {
+				return nil, fmt.Errorf("ca_cert_pem doesn't contain a valid PEM certificate")
+			}
+		}
+
+		if !data.CaCertFile.IsNull() {
+			caBytes, err := os.ReadFile(data.CaCertFile.ValueString())
+			if err != nil {
+				return nil, fmt.Errorf("failed to read ca_cert_file: %w", err)
+			}
+			if !certPool.AppendCertsFromPEM(caBytes) {
+				return nil, fmt.Errorf("ca_cert_file %s doesn't contain a valid PEM certificate", data.CaCertFile.ValueString())
+			}
+		}
+
+		tlsConfig.RootCAs = certPool
+	}
+
+	if data.ClientCertPem.IsNull() != data.ClientKeyPem.IsNull() {
+		return nil, fmt.Errorf("client_cert_pem and client_key_pem must be set together")
+	}
+
+	if !data.ClientCertPem.IsNull() {
+		clientCert, err := tls.X509KeyPair([]byte(data.ClientCertPem.ValueString()), []byte(data.ClientKeyPem.ValueString()))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return &http.Transport{TLSClientConfig: tlsConfig}, nil
+	}
+
+	transport := defaultTransport.Clone()
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+func (client *conductorHttpClient) hasAuthKey() bool {
+	return client.authKey != "" && client.authSecret != ""
+}
+
+func (client *conductorHttpClient) currentToken() string {
+	client.tokenLock.Lock()
+	defer client.tokenLock.Unlock()
+	return client.token
+}
+
+// refreshToken exchanges the auth key and secret for a new token.
+// expiredToken is the token that was rejected, if another request already refreshed it, the refresh is skipped.
+func (client *conductorHttpClient) refreshToken(ctx context.Context, expiredToken string) error {
+	client.tokenLock.Lock()
+	defer client.tokenLock.Unlock()
+
+	if expiredToken != "" && client.token != expiredToken {
+		return nil
+	}
+
+	requestBytes, err := json.Marshal(conductorTokenRequest{KeyId: client.authKey, KeySecret: client.authSecret})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/token", client.endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(requestBytes))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
 	tflog.Debug(ctx, fmt.Sprintf("HTTP Rest Call, Method: %s, URL: %s", req.Method, req.URL))
-	return client.httpClient.Do(req)
+	response, err := client.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("token request failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	bodyBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("token request failed to read response body: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("token request received non-OK HTTP status: %s. Body: %s", response.Status, string(bodyBytes))
+	}
+
+	var tokenResponse conductorTokenResponse
+	err = json.Unmarshal(bodyBytes, &tokenResponse)
+	if err != nil {
+		return fmt.Errorf("token response JSON parse error: %w", err)
+	}
+
+	if tokenResponse.Token == "" {
+		return fmt.Errorf("token response is missing the 'token' field")
+	}
+
+	client.token = tokenResponse.Token
+	tflog.Debug(ctx, "Conductor auth token refreshed")
+	return nil
 }
 
-func (client *conductorHttpClient) do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
-	req, err := client.createRequest(method, path, body)
+func (client *conductorHttpClient) sendRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+	response, err := client.loggedDo(ctx, req)
+	if err != nil || response.StatusCode != http.StatusUnauthorized || !client.hasAuthKey() {
+		return response, err
+	}
+
+	//token expired, refresh and retry once
+	response.Body.Close()
+
+	err = client.refreshToken(ctx, req.Header.Get(authorizationHeader))
 	if err != nil {
-		return nil, err
+		return nil, &tokenRefreshError{err: err}
+	}
+
+	retryReq := req.Clone(ctx)
+	if req.GetBody != nil {
+		retryReq.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
 	}
-	return client.sendRequest(ctx, req)
+	retryReq.Header.Set(authorizationHeader, client.currentToken())
+
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "HTTP Rest Call Retry after token refresh")
+	return client.loggedDo(client.logContext(ctx), retryReq)
+}
+
+func (client *conductorHttpClient) Do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	ctx = client.logContext(ctx)
+
+	//buffer the body so every retry resends the full manifest
+	var bodyBytes []byte
+	if body != nil {
+		var err error
+		bodyBytes, err = io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		var attemptBody io.Reader
+		if body != nil {
+			attemptBody = bytes.NewReader(bodyBytes)
+		}
+
+		req, err := client.createRequest(ctx, method, path, attemptBody)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := client.sendRequest(ctx, req)
+
+		if attempt >= client.maxRetries || !isRetryableMethod(method) || !isRetryableResponse(response, err) {
+			return response, err
+		}
+
+		wait := client.retryWait(attempt, response)
+
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("HTTP Rest Call failed, Method: %s, URL: %s, Error: %s. Retry %d/%d in %s",
+				method, req.URL, err, attempt+1, client.maxRetries, wait))
+		} else {
+			tflog.Warn(ctx, fmt.Sprintf("HTTP Rest Call failed, Method: %s, URL: %s, Status: %s. Retry %d/%d in %s",
+				method, req.URL, response.Status, attempt+1, client.maxRetries, wait))
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isRetryableMethod returns true for idempotent methods, metadata updates are PUT requests and are safe to resend.
+func isRetryableMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isRetryableResponse(response *http.Response, err error) bool {
+	if err != nil {
+		return isTransientError(err)
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isTransientError returns true for transport errors worth a retry - connection reset / refused, EOF, network timeouts.
+// TLS, unknown host and token refresh errors will fail again, so they are returned immediately.
+func isTransientError(err error) bool {
+	var refreshErr *tokenRefreshError
+	if errors.As(err, &refreshErr) {
+		return false
+	}
+
+	if isTLSError(err) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	//url.Error and net.OpError implement net.Error as well, only timeouts are transient
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isTLSError returns true for handshake alerts sent by the server (e.g. bad certificate, certificate required)
+// and certificate verification errors.
+func isTLSError(err error) bool {
+	var alertErr tls.AlertError
+	var certVerificationErr *tls.CertificateVerificationError
+	if errors.As(err, &alertErr) || errors.As(err, &certVerificationErr) {
+		return true
+	}
+
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certInvalidErr x509.CertificateInvalidError
+	var systemRootsErr x509.SystemRootsError
+	var constraintErr x509.ConstraintViolationError
+	var criticalExtensionErr x509.UnhandledCriticalExtension
+	var insecureAlgorithmErr x509.InsecureAlgorithmError
+	return errors.As(err, &unknownAuthorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &certInvalidErr) ||
+		errors.As(err, &systemRootsErr) || errors.As(err, &constraintErr) || errors.As(err, &criticalExtensionErr) ||
+		errors.As(err, &insecureAlgorithmErr)
+}
+
+// retryWait returns the Retry-After header value when present, otherwise an exponential backoff with jitter.
+// The result is always capped by retryMaxWait.
+func (client *conductorHttpClient) retryWait(attempt int, response *http.Response) time.Duration {
+	if response != nil {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			return min(max(retryAfter, client.retryMinWait), client.retryMaxWait)
+		}
+	}
+
+	backoff := client.retryMinWait << attempt
+	if backoff <= 0 || backoff > client.retryMaxWait {
+		backoff = client.retryMaxWait
+	}
+
+	//equal jitter, half fixed and half random
+	half := backoff / 2
+	if half <= 0 {
+		return backoff
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
 }
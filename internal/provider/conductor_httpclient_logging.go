@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const httpLogSubsystem = "conductor_http"
+
+const redactedValue = "***"
+
+// logContext creates the http logging subsystem with masking of secrets and the configured sensitive manifest fields.
+func (client *conductorHttpClient) logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, httpLogSubsystem)
+
+	secrets := make([]string, 0, len(client.headers)+2)
+	for _, value := range client.headers {
+		if value != "" {
+			secrets = append(secrets, value)
+		}
+	}
+	if client.authSecret != "" {
+		secrets = append(secrets, client.authSecret)
+	}
+	if token := client.currentToken(); token != "" {
+		secrets = append(secrets, token)
+	}
+
+	ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, httpLogSubsystem, secrets...)
+	ctx = tflog.SubsystemMaskMessageStrings(ctx, httpLogSubsystem, secrets...)
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, httpLogSubsystem, client.sensitiveFieldRegexes...)
+
+	return ctx
+}
+
+// sensitiveFieldsRegexes matches "field": value pairs inside JSON bodies.
+func sensitiveFieldsRegexes(fields []string) []*regexp.Regexp {
+	regexes := make([]*regexp.Regexp, 0, len(fields))
+	for _, field := range fields {
+		regexes = append(regexes, regexp.MustCompile(
+			fmt.Sprintf(`"%s"\s*:\s*("(?:[^"\\]|\\.)*"|[^,}\]\s]+)`, regexp.QuoteMeta(field))))
+	}
+	return regexes
+}
+
+func (client *conductorHttpClient) redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for key, values := range header {
+		value := strings.Join(values, ", ")
+		if client.isSensitiveHeader(key) {
+			value = redactedValue
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+func (client *conductorHttpClient) isSensitiveHeader(key string) bool {
+	if strings.EqualFold(key, authorizationHeader) || strings.EqualFold(key, "Authorization") {
+		return true
+	}
+
+	for headerKey := range client.headers {
+		if strings.EqualFold(key, headerKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// loggedDo sends the request and logs the request and response at TRACE level.
+// The response body is buffered so it can be logged and still read by the caller.
+func (client *conductorHttpClient) loggedDo(ctx context.Context, req *http.Request) (*http.Response, error) {
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, fmt.Sprintf("HTTP Rest Call, Method: %s, URL: %s", req.Method, req.URL))
+
+	requestFields := map[string]interface{}{
+		"http_method":          req.Method,
+		"http_url":             req.URL.String(),
+		"http_request_headers": client.redactHeaders(req.Header),
+	}
+	if req.GetBody != nil {
+		if bodyCopy, err := req.GetBody(); err == nil {
+			bodyBytes, _ := io.ReadAll(bodyCopy)
+			requestFields["http_request_body"] = string(bodyBytes)
+		}
+	}
+	tflog.SubsystemTrace(ctx, httpLogSubsystem, "HTTP Request", requestFields)
+
+	start := time.Now()
+	response, err := client.httpClient.Do(req)
+	duration := time.Since(start)
+
+	if err != nil {
+		tflog.SubsystemTrace(ctx, httpLogSubsystem, "HTTP Request Failed", map[string]interface{}{
+			"http_method":      req.Method,
+			"http_url":         req.URL.String(),
+			"http_duration_ms": duration.Milliseconds(),
+			"error":            err.Error(),
+		})
+		return response, err
+	}
+
+	bodyBytes, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	response.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+
+	tflog.SubsystemTrace(ctx, httpLogSubsystem, "HTTP Response", map[string]interface{}{
+		"http_method":           req.Method,
+		"http_url":              req.URL.String(),
+		"http_status":           response.StatusCode,
+		"http_duration_ms":      duration.Milliseconds(),
+		"http_response_headers": client.redactHeaders(response.Header),
+		"http_response_body":    string(bodyBytes),
+	})
+
+	return response, nil
+}
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func newTestHttpClient(server *httptest.Server) *conductorHttpClient {
+	return &conductorHttpClient{
+		httpClient:   server.Client(),
+		endpoint:     server.URL + "/api",
+		headers:      make(map[string]string),
+		maxRetries:   3,
+		retryMinWait: time.Millisecond,
+		retryMaxWait: 5 * time.Millisecond,
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "empty", value: "", ok: false},
+		{name: "seconds", value: "5", expected: 5 * time.Second, ok: true},
+		{name: "zero", value: "0", expected: 0, ok: true},
+		{name: "negative", value: "-1", ok: false},
+		{name: "invalid", value: "soon", ok: false},
+		{name: "past date", value: "Mon, 02 Jan 2006 15:04:05 GMT", expected: 0, ok: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wait, ok := parseRetryAfter(test.value)
+			if ok != test.ok || wait != test.expected {
+				t.Errorf("parseRetryAfter(%q) = %s, %t, expected %s, %t", test.value, wait, ok, test.expected, test.ok)
+			}
+		})
+	}
+
+	future := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	wait, ok := parseRetryAfter(future)
+	if !ok || wait <= 0 || wait > 10*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %s, %t, expected a wait up to 10s", future, wait, ok)
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	client := &conductorHttpClient{retryMinWait: time.Second, retryMaxWait: 30 * time.Second}
+
+	retryAfterTests := []struct {
+		name       string
+		retryAfter string
+		expected   time.Duration
+	}{
+		{name: "retry after", retryAfter: "10", expected: 10 * time.Second},
+		{name: "capped by max wait", retryAfter: "120", expected: 30 * time.Second},
+		{name: "raised to min wait", retryAfter: "0", expected: time.Second},
+	}
+
+	for _, test := range retryAfterTests {
+		t.Run(test.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{"Retry-After": []string{test.retryAfter}}}
+			if wait := client.retryWait(0, response); wait != test.expected {
+				t.Errorf("retryWait = %s, expected %s", wait, test.expected)
+			}
+		})
+	}
+
+	backoffTests := []struct {
+		attempt int
+		backoff time.Duration
+	}{
+		{attempt: 0, backoff: time.Second},
+		{attempt: 1, backoff: 2 * time.Second},
+		{attempt: 3, backoff: 8 * time.Second},
+		{attempt: 10, backoff: 30 * time.Second},
+		{attempt: 100, backoff: 30 * time.Second},
+	}
+
+	for _, test := range backoffTests {
+		t.Run(fmt.Sprintf("backoff attempt %d", test.attempt), func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				wait := client.retryWait(test.attempt, nil)
+				if wait < test.backoff/2 || wait > test.backoff {
+					t.Fatalf("retryWait = %s, expected between %s and %s", wait, test.backoff/2, test.backoff)
+				}
+			}
+		})
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	urlError := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://conductor/api", Err: err}
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "eof", err: urlError(io.EOF), expected: true},
+		{name: "connection reset", err: urlError(syscall.ECONNRESET), expected: true},
+		{name: "connection refused", err: urlError(syscall.ECONNREFUSED), expected: true},
+		{name: "wrapped connection reset", err: urlError(&net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), expected: true},
+		{name: "timeout", err: urlError(&net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}), expected: true},
+		{name: "tls bad certificate alert", err: urlError(&net.OpError{Op: "remote error", Err: tls.AlertError(42)}), expected: false},
+		{name: "tls certificate required alert", err: urlError(&net.OpError{Op: "remote error", Err: tls.AlertError(116)}), expected: false},
+		{name: "dns not found", err: urlError(&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "conductor", IsNotFound: true}}), expected: false},
+		{name: "other op error", err: urlError(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("boom")}), expected: false},
+		{name: "unknown authority", err: urlError(x509.UnknownAuthorityError{}), expected: false},
+		{name: "hostname", err: urlError(x509.HostnameError{Host: "conductor"}), expected: false},
+		{name: "token refresh", err: &tokenRefreshError{err: urlError(io.EOF)}, expected: false},
+		{name: "other", err: errors.New("boom"), expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := isTransientError(test.err); actual != test.expected {
+				t.Errorf("isTransientError(%s) = %t, expected %t", test.err, actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestDoResendsBodyOnRetry(t *testing.T) {
+	var lock sync.Mutex
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		lock.Lock()
+		bodies = append(bodies, string(body))
+		attempt := len(bodies)
+		lock.Unlock()
+
+		if attempt < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newTestHttpClient(server)
+
+	response, err := client.Do(context.Background(), http.MethodPut, "metadata/workflow", strings.NewReader(`[{"name":"wf"}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, expected %d", response.StatusCode, http.StatusOK)
+	}
+
+	if len(bodies) != 3 {
+		t.Fatalf("requests = %d, expected 3", len(bodies))
+	}
+
+	for i, body := range bodies {
+		if body != `[{"name":"wf"}]` {
+			t.Errorf("request %d body = %q, expected the full body", i, body)
+		}
+	}
+}
+
+func TestDoDoesNotRetryPost(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	response, err := newTestHttpClient(server).Do(context.Background(), http.MethodPost, "metadata/taskdefs", strings.NewReader(`[]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	response.Body.Close()
+
+	if requests != 1 {
+		t.Errorf("requests = %d, expected 1", requests)
+	}
+}
+
+func TestDoReturnsTokenRefreshErrorImmediately(t *testing.T) {
+	requests := 0
+	tokenRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/token" {
+			tokenRequests++
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := newTestHttpClient(server)
+	client.authKey = "key"
+	client.authSecret = "secret"
+	client.token = "expired"
+
+	_, err := client.Do(context.Background(), http.MethodGet, "metadata/taskdefs", nil)
+
+	var refreshErr *tokenRefreshError
+	if !errors.As(err, &refreshErr) {
+		t.Fatalf("error = %v, expected a token refresh error", err)
+	}
+
+	if requests != 1 || tokenRequests != 1 {
+		t.Errorf("requests = %d, token requests = %d, expected 1 and 1", requests, tokenRequests)
+	}
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	manifestKindTaskDef      = "taskdef"
+	manifestKindWorkflowDef  = "workflowdef"
+	manifestKindEventHandler = "event_handler"
+)
+
+var (
+	taskDefManifestType      = ConductorManifestType{kind: manifestKindTaskDef}
+	workflowDefManifestType  = ConductorManifestType{kind: manifestKindWorkflowDef}
+	eventHandlerManifestType = ConductorManifestType{kind: manifestKindEventHandler}
+)
+
+// manifestDefaultsCleanups removes the server default values of each resource manifest kind.
+var manifestDefaultsCleanups = map[string]func(ctx context.Context, manifestMap map[string]interface{}){
+	manifestKindTaskDef: func(ctx context.Context, manifestMap map[string]interface{}) {
+		cleanupManifestDefaults(ctx, manifestMap, defaultTaskDefValues)
+	},
+	manifestKindWorkflowDef:  workflowDefCleanup,
+	manifestKindEventHandler: eventHandlerCleanup,
+}
+
+var _ basetypes.StringTypable = ConductorManifestType{}
+
+// ConductorManifestType is a JSON manifest string type.
+// Two manifests are semantically equal when they are equal once the server default values of the resource are removed.
+type ConductorManifestType struct {
+	basetypes.StringType
+	kind string
+}
+
+func (t ConductorManifestType) String() string {
+	return fmt.Sprintf("ConductorManifestType(%s)", t.kind)
+}
+
+func (t ConductorManifestType) ValueType(_ context.Context) attr.Value {
+	return ConductorManifest{kind: t.kind}
+}
+
+func (t ConductorManifestType) Equal(o attr.Type) bool {
+	other, ok := o.(ConductorManifestType)
+	if !ok {
+		return false
+	}
+
+	return t.kind == other.kind
+}
+
+func (t ConductorManifestType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return ConductorManifest{StringValue: in, kind: t.kind}, nil
+}
+
+func (t ConductorManifestType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+var _ basetypes.StringValuableWithSemanticEquals = ConductorManifest{}
+var _ xattr.ValidateableAttribute = ConductorManifest{}
+
+// ConductorManifest is a value of ConductorManifestType.
+type ConductorManifest struct {
+	basetypes.StringValue
+	kind string
+}
+
+// NewConductorManifestValue creates a known manifest value of the given manifest type.
+func NewConductorManifestValue(manifestType ConductorManifestType, value string) ConductorManifest {
+	return ConductorManifest{
+		StringValue: basetypes.NewStringValue(value),
+		kind:        manifestType.kind,
+	}
+}
+
+func (v ConductorManifest) Type(_ context.Context) attr.Type {
+	return ConductorManifestType{kind: v.kind}
+}
+
+func (v ConductorManifest) Equal(o attr.Value) bool {
+	other, ok := o.(ConductorManifest)
+	if !ok {
+		return false
+	}
+
+	return v.kind == other.kind && v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals compares the manifests after removing the server default values of the resource.
+func (v ConductorManifest) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(ConductorManifest)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T", v, newValuable))
+		return false, diags
+	}
+
+	var currentMap map[string]interface{}
+	if err := json.Unmarshal([]byte(v.ValueString()), &currentMap); err != nil {
+		return false, diags
+	}
+
+	var newMap map[string]interface{}
+	if err := json.Unmarshal([]byte(newValue.ValueString()), &newMap); err != nil {
+		return false, diags
+	}
+
+	if cleanup, ok := manifestDefaultsCleanups[v.kind]; ok {
+		cleanup(ctx, currentMap)
+		cleanup(ctx, newMap)
+	}
+
+	return reflect.DeepEqual(currentMap, newMap), diags
+}
+
+// ValidateAttribute requires the manifest to be a valid JSON object.
+func (v ConductorManifest) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	var manifestMap map[string]interface{}
+	if err := json.Unmarshal([]byte(v.ValueString()), &manifestMap); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Manifest", fmt.Sprintf("Manifest must be a valid json object: %s", err))
+	}
+}
+
+// Unmarshal calls json.Unmarshal with the manifest value, null or unknown values produce an error diagnostic.
+func (v ConductorManifest) Unmarshal(target any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if v.IsNull() || v.IsUnknown() {
+		diags.AddError("Manifest JSON Unmarshal Error", "manifest value is null or unknown")
+		return diags
+	}
+
+	if err := json.Unmarshal([]byte(v.ValueString()), target); err != nil {
+		diags.AddError("Manifest JSON Unmarshal Error", err.Error())
+	}
+
+	return diags
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+func effectiveManifestAttribute() tfschema.StringAttribute {
+	return tfschema.StringAttribute{
+		MarkdownDescription: "The complete JSON manifest as stored by Conductor, including server defaults and server added fields. " +
+			"Auditable fields (`createTime`, `updateTime`, `createdBy`, `updatedBy`) are excluded",
+		Computed:   true,
+		CustomType: jsontypes.NormalizedType{},
+	}
+}
+
+// effectiveManifestValue returns the server manifest without the auditable fields, the manifest map is not modified.
+func effectiveManifestValue(currentManifestMap map[string]interface{}, diagnostics *diag.Diagnostics) jsontypes.Normalized {
+	effectiveMap := make(map[string]interface{}, len(currentManifestMap))
+	for key, value := range currentManifestMap {
+		effectiveMap[key] = value
+	}
+
+	for _, f := range auditableFieldsToIgnore {
+		delete(effectiveMap, f)
+	}
+
+	effectiveBytes, err := json.Marshal(effectiveMap)
+	if err != nil {
+		diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Effective manifest must be a valid json: %s", err))
+		return jsontypes.NewNormalizedNull()
+	}
+
+	return jsontypes.NewNormalizedValue(string(effectiveBytes))
+}
@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorapi"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	tfschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var eventHandlerActionTypes = []string{"start_workflow", "complete_task", "fail_task", "terminate_workflow", "update_workflow_variables"}
+
+var _ tfresource.Resource = &EventHandlerResource{}
+var _ tfresource.ResourceWithImportState = &EventHandlerResource{}
+var _ tfresource.ResourceWithModifyPlan = &EventHandlerResource{}
+
+type EventHandlerResource struct {
+	client *conductorapi.Client
+}
+
+type EventHandlerModel struct {
+	Manifest ConductorManifest `tfsdk:"manifest"`
+	Timeouts timeouts.Value    `tfsdk:"timeouts"`
+}
+
+func NewEventHandlerResource() tfresource.Resource {
+	return &EventHandlerResource{}
+}
+
+func (r *EventHandlerResource) Metadata(ctx context.Context, req tfresource.MetadataRequest, resp *tfresource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_event_handler"
+}
+
+func (r *EventHandlerResource) Schema(ctx context.Context, req tfresource.SchemaRequest, resp *tfresource.SchemaResponse) {
+	resp.Schema = tfschema.Schema{
+		Description: "Conductor Event Handler",
+		MarkdownDescription: `
+Conductor Event Handler
+## Actions
+Every action in the "actions" list must have an "action" field, one of: start_workflow, complete_task, fail_task, terminate_workflow, update_workflow_variables.
+The action parameters are set in the field with the same name as the action, e.g. - "start_workflow": {"name": "workflow_name"}.
+		`,
+		Attributes: map[string]tfschema.Attribute{
+			"manifest": tfschema.StringAttribute{
+				Description: "The JSON Manifest for the event handler",
+				Required:    true,
+				CustomType:  eventHandlerManifestType,
+				PlanModifiers: []planmodifier.String{
+					nameChangedModifier{},
+				},
+				Validators: []validator.String{
+					manifestNameValidator{},
+					eventHandlerManifestValidator{},
+				},
+			},
+		},
+		Blocks: map[string]tfschema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *EventHandlerResource) Configure(ctx context.Context, req tfresource.ConfigureRequest, resp *tfresource.ConfigureResponse) {
+	if req.ProviderData == nil { // this means the provider.go Configure method hasn't been called yet, so wait longer
+		return
+	}
+	provider, ok := req.ProviderData.(*ConductorProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Could not create Conductor Provider",
+			fmt.Sprintf("Expected *ConductorProvider, got: %T", req.ProviderData),
+		)
+		return
+	}
+	r.client = provider.client
+}
+
+func (r *EventHandlerResource) ModifyPlan(ctx context.Context, req tfresource.ModifyPlanRequest, resp *tfresource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan EventHandlerModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Manifest.IsNull() || plan.Manifest.IsUnknown() {
+		return
+	}
+
+	var state EventHandlerModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Manifest.IsNull() || state.Manifest.IsUnknown() {
+		return
+	}
+
+	equal, diags := plan.Manifest.StringSemanticEquals(ctx, state.Manifest)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if equal {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("manifest"), state.Manifest)...)
+	}
+}
+
+func (r *EventHandlerResource) Create(ctx context.Context, req tfresource.CreateRequest, resp *tfresource.CreateResponse) {
+	var state EventHandlerModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := state.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var manifestMap map[string]interface{}
+
+	resp.Diagnostics.Append(state.Manifest.Unmarshal(&manifestMap)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateEventHandler(ctx, manifestMap)
+	if err != nil {
+		addManifestErrorDiagnostics(&resp.Diagnostics, "Unable to create event handler", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *EventHandlerResource) Read(ctx context.Context, req tfresource.ReadRequest, resp *tfresource.ReadResponse) {
+	var state EventHandlerModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	var stateManifestMap map[string]interface{}
+
+	resp.Diagnostics.Append(state.Manifest.Unmarshal(&stateManifestMap)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := getManifestStringValue(stateManifestMap, "name", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	currentManifestMap, err := r.client.GetEventHandlerManifest(ctx, name)
+	if conductorapi.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read event handler, got error: %s", err))
+		return
+	}
+
+	eventHandlerCleanupAndMerge(ctx, currentManifestMap, stateManifestMap)
+
+	updatedStateBytes, err := json.Marshal(stateManifestMap)
+	if err != nil {
+		resp.Diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Manifest must be a valid json: %s", err))
+		return
+	}
+
+	state.Manifest = NewConductorManifestValue(eventHandlerManifestType, string(updatedStateBytes))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *EventHandlerResource) Delete(ctx context.Context, req tfresource.DeleteRequest, resp *tfresource.DeleteResponse) {
+	var state EventHandlerModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	var manifestMap map[string]interface{}
+
+	resp.Diagnostics.Append(state.Manifest.Unmarshal(&manifestMap)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := getManifestStringValue(manifestMap, "name", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteEventHandler(ctx, name)
+	if err != nil && !conductorapi.IsNotFound(err) {
+		resp.Diagnostics.AddError("Delete Error", fmt.Sprintf("Unable to delete event handler, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *EventHandlerResource) Update(ctx context.Context, req tfresource.UpdateRequest, resp *tfresource.UpdateResponse) {
+	var state EventHandlerModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := state.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var manifestMap map[string]interface{}
+	resp.Diagnostics.Append(state.Manifest.Unmarshal(&manifestMap)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateEventHandler(ctx, manifestMap)
+	if conductorapi.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		addManifestErrorDiagnostics(&resp.Diagnostics, "Unable to update event handler", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *EventHandlerResource) ImportState(ctx context.Context, req tfresource.ImportStateRequest, resp *tfresource.ImportStateResponse) {
+
+	initialStateMap := map[string]interface{}{
+		"name": req.ID,
+	}
+
+	manifestBytes, err := json.Marshal(initialStateMap)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Manifest Marshal error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("manifest"), string(manifestBytes))...)
+}
+
+func eventHandlerCleanupAndMerge(ctx context.Context, currentManifestMap map[string]interface{}, stateManifestMap map[string]interface{}) {
+	eventHandlerCleanup(ctx, currentManifestMap)
+	mergeManifestMaps(ctx, currentManifestMap, stateManifestMap, nil)
+}
+
+// eventHandlerCleanup removes the server default values, the event handler defaults ('active' and the action 'expandInlineJSON' false)
+// are the generic false / 0 / "" defaults, so no default values are declared.
+func eventHandlerCleanup(ctx context.Context, manifestMap map[string]interface{}) {
+	actionsVal, ok := manifestMap["actions"]
+	if ok {
+		actionsArr, ok := actionsVal.([]interface{})
+		if !ok {
+			tflog.Error(ctx, fmt.Sprintf("map 'actions' key is not valid a slice. type: %T", actionsVal))
+		}
+
+		for i := 0; i < len(actionsArr); i++ {
+			action, ok := actionsArr[i].(map[string]interface{})
+			if !ok {
+				tflog.Error(ctx, fmt.Sprintf("map 'action' index: %d, is not valid a map. type: %T", i, actionsArr[i]))
+				continue
+			}
+
+			//action parameters, e.g. - start_workflow
+			for _, actionType := range eventHandlerActionTypes {
+				if actionParams, ok := action[actionType].(map[string]interface{}); ok {
+					cleanupManifestDefaults(ctx, actionParams, nil)
+				}
+			}
+
+			cleanupManifestDefaults(ctx, action, nil)
+		}
+	}
+
+	cleanupManifestDefaults(ctx, manifestMap, nil)
+}
+
+type eventHandlerManifestValidator struct{}
+
+func (m eventHandlerManifestValidator) Description(_ context.Context) string {
+	return "Validate the event handler 'event' and 'actions' fields"
+}
+
+func (m eventHandlerManifestValidator) MarkdownDescription(c context.Context) string {
+	return m.Description(c)
+}
+
+func (m eventHandlerManifestValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var planMap map[string]interface{}
+	err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &planMap)
+	if err != nil {
+		return
+	}
+
+	eventStr, ok := planMap["event"].(string)
+	if !ok || eventStr == "" {
+		resp.Diagnostics.AddAttributeError(req.Path, "'event' parameter must be a non empty string", "")
+	}
+
+	actionsArr, ok := planMap["actions"].([]interface{})
+	if !ok || len(actionsArr) == 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "'actions' parameter must be a non empty array", "")
+		return
+	}
+
+	for i, actionVal := range actionsArr {
+		action, ok := actionVal.(map[string]interface{})
+		if !ok {
+			resp.Diagnostics.AddAttributeError(req.Path, fmt.Sprintf("'actions[%d]' must be an object", i), "")
+			continue
+		}
+
+		actionType, _ := action["action"].(string)
+		if !slices.Contains(eventHandlerActionTypes, actionType) {
+			resp.Diagnostics.AddAttributeError(req.Path, fmt.Sprintf("'actions[%d].action' is not valid", i),
+				fmt.Sprintf("Supported actions: %s", strings.Join(eventHandlerActionTypes, ", ")))
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEventHandlerCleanup(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		expected string
+	}{
+		{
+			name: "server defaults removed",
+			manifest: `{"name":"handler","event":"kafka:topic","active":false,"evaluatorType":"",
+				"actions":[{"action":"start_workflow","expandInlineJSON":false,"start_workflow":{"name":"wf","input":{},"version":0}}]}`,
+			expected: `{"name":"handler","event":"kafka:topic",
+				"actions":[{"action":"start_workflow","start_workflow":{"name":"wf"}}]}`,
+		},
+		{
+			name: "configured values kept",
+			manifest: `{"name":"handler","event":"kafka:topic","active":true,
+				"actions":[{"action":"complete_task","expandInlineJSON":true,"complete_task":{"taskRefName":"task_ref","output":{"a":"b"}}}]}`,
+			expected: `{"name":"handler","event":"kafka:topic","active":true,
+				"actions":[{"action":"complete_task","expandInlineJSON":true,"complete_task":{"taskRefName":"task_ref","output":{"a":"b"}}}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manifest := unmarshalTestJSON[map[string]interface{}](t, test.manifest)
+			expected := unmarshalTestJSON[map[string]interface{}](t, test.expected)
+
+			eventHandlerCleanup(context.Background(), manifest)
+			if !reflect.DeepEqual(manifest, expected) {
+				manifestBytes, _ := json.Marshal(manifest)
+				t.Errorf("cleaned manifest = %s, expected %s", manifestBytes, test.expected)
+			}
+		})
+	}
+}
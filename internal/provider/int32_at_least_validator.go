@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type int32AtLeastValidator struct {
+	min int32
+}
+
+func (m int32AtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("Value must be at least %d", m.min)
+}
+
+func (m int32AtLeastValidator) MarkdownDescription(c context.Context) string {
+	return m.Description(c)
+}
+
+func (m int32AtLeastValidator) ValidateInt32(_ context.Context, req validator.Int32Request, resp *validator.Int32Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if req.ConfigValue.ValueInt32() < m.min {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid value",
+			fmt.Sprintf("Value must be at least %d, got: %d", m.min, req.ConfigValue.ValueInt32()))
+	}
+}
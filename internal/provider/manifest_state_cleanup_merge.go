@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -93,12 +94,71 @@ func isPrimitiveValue(value interface{}) bool {
 	return false
 }
 
-func mergeManifestMaps(_ context.Context, fromMap map[string]interface{}, toMap map[string]interface{}) {
+// mergeManifestMaps merges the current server manifest (fromMap) into the state manifest (toMap).
+// Nested maps are merged recursively, arrays and primitives are taken from the server and keys removed remotely
+// are removed from the state, unless the state value is a default value the server does not return.
+func mergeManifestMaps(ctx context.Context, fromMap map[string]interface{}, toMap map[string]interface{},
+	defaultValues map[string]interface{}) {
+
 	for key, fromValue := range fromMap {
-		_, exists := toMap[key]
-		if !exists || isPrimitiveValue(fromValue) {
-			toMap[key] = fromValue
+		fromMapVal, fromIsMap := fromValue.(map[string]interface{})
+		toMapVal, toIsMap := toMap[key].(map[string]interface{})
+		if fromIsMap && toIsMap {
+			mergeManifestMaps(ctx, fromMapVal, toMapVal, nil)
+			continue
+		}
+
+		toMap[key] = fromValue
+	}
+
+	for key, toValue := range toMap {
+		if _, exists := fromMap[key]; exists {
+			continue
+		}
+
+		if isDefaultManifestValue(key, toValue, defaultValues) {
 			continue
 		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Key: %s, removed remotely", key))
+		delete(toMap, key)
+	}
+}
+
+// isDefaultManifestValue returns true when the value is omitted by the server, null, empty or a default value.
+func isDefaultManifestValue(key string, value interface{}, defaultValues map[string]interface{}) bool {
+	if value == nil {
+		return true
 	}
+
+	if isPrimitiveValue(value) {
+		return reflect.DeepEqual(value, getPrimitiveDefaultValue(key, value, defaultValues))
+	}
+
+	if mapVal, isMap := value.(map[string]interface{}); isMap {
+		return len(mapVal) == 0
+	}
+
+	if sliceVal, isSlice := value.([]interface{}); isSlice {
+		return len(sliceVal) == 0
+	}
+
+	return false
+}
+
+// getManifestStringValue returns the non empty string value of the manifest key, or adds an error diagnostic.
+func getManifestStringValue(manifestMap map[string]interface{}, key string, diagnostics *diag.Diagnostics) string {
+	val, ok := manifestMap[key]
+	if !ok {
+		diagnostics.AddError("Invalid Manifest", fmt.Sprintf("'%s' parameter is missing from manifest", key))
+		return ""
+	}
+
+	value, ok := val.(string)
+	if !ok || value == "" {
+		diagnostics.AddError("Invalid Manifest", fmt.Sprintf("'%s' parameter must be string", key))
+		return ""
+	}
+
+	return value
 }
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergeManifestMaps(t *testing.T) {
+	defaultValues := map[string]interface{}{
+		"retryCount":    float64(3),
+		"timeoutPolicy": "TIME_OUT_WF",
+	}
+
+	tests := []struct {
+		name     string
+		current  string
+		state    string
+		expected string
+	}{
+		{
+			name:     "changed remotely",
+			current:  `{"name":"task","retryCount":5,"ownerEmail":"new@example.com"}`,
+			state:    `{"name":"task","retryCount":4,"ownerEmail":"old@example.com"}`,
+			expected: `{"name":"task","retryCount":5,"ownerEmail":"new@example.com"}`,
+		},
+		{
+			name:     "added remotely",
+			current:  `{"name":"task","description":"added"}`,
+			state:    `{"name":"task"}`,
+			expected: `{"name":"task","description":"added"}`,
+		},
+		{
+			name:     "nested maps changed remotely",
+			current:  `{"name":"task","inputTemplate":{"a":"changed","c":{"d":1}}}`,
+			state:    `{"name":"task","inputTemplate":{"a":"configured","b":"removed","c":{"d":2,"e":""}}}`,
+			expected: `{"name":"task","inputTemplate":{"a":"changed","c":{"d":1,"e":""}}}`,
+		},
+		{
+			name:     "arrays taken from the server",
+			current:  `{"name":"task","inputKeys":["b","a"]}`,
+			state:    `{"name":"task","inputKeys":["a"]}`,
+			expected: `{"name":"task","inputKeys":["b","a"]}`,
+		},
+		{
+			name:     "keys removed remotely",
+			current:  `{"name":"task"}`,
+			state:    `{"name":"task","ownerEmail":"owner@example.com","retryCount":5,"inputKeys":["a"],"inputTemplate":{"a":"b"}}`,
+			expected: `{"name":"task"}`,
+		},
+		{
+			name:     "default values kept",
+			current:  `{"name":"task"}`,
+			state:    `{"name":"task","retryCount":3,"timeoutPolicy":"TIME_OUT_WF","description":"","enforceSchema":false,"timeoutSeconds":0,"inputKeys":[],"inputTemplate":{},"ownerEmail":null}`,
+			expected: `{"name":"task","retryCount":3,"timeoutPolicy":"TIME_OUT_WF","description":"","enforceSchema":false,"timeoutSeconds":0,"inputKeys":[],"inputTemplate":{},"ownerEmail":null}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current := unmarshalTestJSON[map[string]interface{}](t, test.current)
+			state := unmarshalTestJSON[map[string]interface{}](t, test.state)
+			expected := unmarshalTestJSON[map[string]interface{}](t, test.expected)
+
+			mergeManifestMaps(context.Background(), current, state, defaultValues)
+			if !reflect.DeepEqual(state, expected) {
+				stateBytes, _ := json.Marshal(state)
+				t.Errorf("merged state = %s, expected %s", stateBytes, test.expected)
+			}
+		})
+	}
+}
+
+func TestIsDefaultManifestValue(t *testing.T) {
+	defaultValues := map[string]interface{}{
+		"retryCount":  float64(3),
+		"restartable": true,
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		value    interface{}
+		expected bool
+	}{
+		{name: "nil", key: "ownerEmail", value: nil, expected: true},
+		{name: "declared default", key: "retryCount", value: float64(3), expected: true},
+		{name: "not the declared default", key: "retryCount", value: float64(0), expected: false},
+		{name: "declared bool default", key: "restartable", value: true, expected: true},
+		{name: "not the declared bool default", key: "restartable", value: false, expected: false},
+		{name: "empty string", key: "description", value: "", expected: true},
+		{name: "string", key: "description", value: "text", expected: false},
+		{name: "zero", key: "timeoutSeconds", value: float64(0), expected: true},
+		{name: "number", key: "timeoutSeconds", value: float64(10), expected: false},
+		{name: "false", key: "enforceSchema", value: false, expected: true},
+		{name: "true", key: "enforceSchema", value: true, expected: false},
+		{name: "empty map", key: "inputTemplate", value: map[string]interface{}{}, expected: true},
+		{name: "map", key: "inputTemplate", value: map[string]interface{}{"a": "b"}, expected: false},
+		{name: "empty slice", key: "inputKeys", value: []interface{}{}, expected: true},
+		{name: "slice", key: "inputKeys", value: []interface{}{"a"}, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := isDefaultManifestValue(test.key, test.value, defaultValues); actual != test.expected {
+				t.Errorf("isDefaultManifestValue(%q, %v) = %t, expected %t", test.key, test.value, actual, test.expected)
+			}
+		})
+	}
+}
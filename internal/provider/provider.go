@@ -2,8 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
 
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorapi"
 	tfdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	tffunction "github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
@@ -12,13 +17,35 @@ import (
 	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+const (
+	endpointEnvVar     = "CONDUCTOR_ENDPOINT"
+	authKeyEnvVar      = "CONDUCTOR_AUTH_KEY"
+	authSecretEnvVar   = "CONDUCTOR_AUTH_SECRET"
+	headerEnvVarPrefix = "CONDUCTOR_HEADER_"
+)
+
 type ConductorProviderModel struct {
 	Endpoint      tftypes.String `tfsdk:"endpoint"`
 	CustomHeaders tftypes.Map    `tfsdk:"custom_headers"`
+	AuthKey       tftypes.String `tfsdk:"auth_key"`
+	AuthSecret    tftypes.String `tfsdk:"auth_secret"`
+	MaxRetries    tftypes.Int64  `tfsdk:"max_retries"`
+	RetryMinWait  tftypes.String `tfsdk:"retry_min_wait"`
+	RetryMaxWait  tftypes.String `tfsdk:"retry_max_wait"`
+
+	RequestTimeout tftypes.String `tfsdk:"request_timeout"`
+
+	LogSensitiveFields tftypes.List `tfsdk:"log_sensitive_fields"`
+
+	CaCertPem          tftypes.String `tfsdk:"ca_cert_pem"`
+	CaCertFile         tftypes.String `tfsdk:"ca_cert_file"`
+	ClientCertPem      tftypes.String `tfsdk:"client_cert_pem"`
+	ClientKeyPem       tftypes.String `tfsdk:"client_key_pem"`
+	InsecureSkipVerify tftypes.Bool   `tfsdk:"insecure_skip_verify"`
 }
 
 type ConductorProvider struct {
-	client *conductorHttpClient
+	client *conductorapi.Client
 }
 
 var _ tfprovider.Provider = &ConductorProvider{}
@@ -40,14 +67,65 @@ func (p *ConductorProvider) Schema(ctx context.Context, req tfprovider.SchemaReq
 		MarkdownDescription: "The Conductor Provider used create resource on conductor platform\nThis is an unofficial Terraform provider for Conducotr\nSee Conductor OSS reference: https://github.com/conductor-oss/conductor",
 		Attributes: map[string]tfschema.Attribute{
 			"endpoint": tfschema.StringAttribute{
-				MarkdownDescription: "Endpoint of the Conductor API, the endpoint should include the /api prefix. e.g. - http://localhost:6251/api",
-				Required:            true,
+				MarkdownDescription: "Endpoint of the Conductor API, the endpoint should include the /api prefix. e.g. - http://localhost:6251/api. Can also be set with the `CONDUCTOR_ENDPOINT` environment variable",
+				Optional:            true,
 			},
 			"custom_headers": tfschema.MapAttribute{
-				MarkdownDescription: "Custom http headers to send for every request",
+				MarkdownDescription: "Custom http headers to send for every request. Headers can also be set with `CONDUCTOR_HEADER_<NAME>` environment variables, underscores in the name are replaced with dashes (e.g. `CONDUCTOR_HEADER_X_API_KEY` > `X-API-KEY`), values set here take precedence",
 				Optional:            true,
 				ElementType:         tftypes.StringType,
 			},
+			"auth_key": tfschema.StringAttribute{
+				MarkdownDescription: "Orkes Conductor application key id. Exchanged together with `auth_secret` for a token via the `/token` endpoint, the token is sent as the `X-Authorization` header and refreshed automatically when it expires. Can also be set with the `CONDUCTOR_AUTH_KEY` environment variable",
+				Optional:            true,
+			},
+			"auth_secret": tfschema.StringAttribute{
+				MarkdownDescription: "Orkes Conductor application key secret, required when `auth_key` is set. Can also be set with the `CONDUCTOR_AUTH_SECRET` environment variable",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"max_retries": tfschema.Int64Attribute{
+				MarkdownDescription: "Max number of retries for failed requests (connection errors, 429, 502, 503, 504). Only idempotent requests are retried. Defaults to 3",
+				Optional:            true,
+			},
+			"retry_min_wait": tfschema.StringAttribute{
+				MarkdownDescription: "Min wait between retries as a duration string, e.g. - 500ms. Defaults to 1s",
+				Optional:            true,
+			},
+			"retry_max_wait": tfschema.StringAttribute{
+				MarkdownDescription: "Max wait between retries as a duration string, e.g. - 1m. Also caps the `Retry-After` response header. Defaults to 30s",
+				Optional:            true,
+			},
+			"request_timeout": tfschema.StringAttribute{
+				MarkdownDescription: "Timeout of a single HTTP request as a duration string, e.g. - 2m. `0s` disables the timeout. Defaults to 60s",
+				Optional:            true,
+			},
+			"log_sensitive_fields": tfschema.ListAttribute{
+				MarkdownDescription: "Manifest JSON field names whose values are masked in the HTTP TRACE logs. Custom header values and the auth token are always masked",
+				Optional:            true,
+				ElementType:         tftypes.StringType,
+			},
+			"ca_cert_pem": tfschema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificate(s) used to verify the Conductor server certificate, added to the system CA pool",
+				Optional:            true,
+			},
+			"ca_cert_file": tfschema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded CA certificate(s) file used to verify the Conductor server certificate, added to the system CA pool",
+				Optional:            true,
+			},
+			"client_cert_pem": tfschema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS, requires `client_key_pem`",
+				Optional:            true,
+			},
+			"client_key_pem": tfschema.StringAttribute{
+				MarkdownDescription: "PEM encoded client private key for mutual TLS, requires `client_cert_pem`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": tfschema.BoolAttribute{
+				MarkdownDescription: "Skip the Conductor server certificate verification. Not recommended outside of development",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -59,26 +137,113 @@ func (p *ConductorProvider) Configure(ctx context.Context, req tfprovider.Config
 		return
 	}
 
-	if data.Endpoint.IsNull() || data.Endpoint.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(path.Root("endpoint"), "endpoint can't be null", "")
+	applyEnvDefaults(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	p.client = createConductorHttpClient(ctx, data)
+	if data.Endpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(path.Root("endpoint"), "endpoint must be known during configuration", "")
+		return
+	}
+
+	if data.Endpoint.IsNull() || data.Endpoint.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("endpoint"), "endpoint can't be null",
+			fmt.Sprintf("The Conductor endpoint was not found in any of the checked sources:\n"+
+				"- provider \"endpoint\" attribute\n"+
+				"- %s environment variable", endpointEnvVar))
+		return
+	}
+
+	if data.AuthKey.IsUnknown() || data.AuthSecret.IsUnknown() {
+		resp.Diagnostics.AddError("auth_key and auth_secret must be known during configuration", "")
+		return
+	}
+
+	if data.AuthKey.IsNull() != data.AuthSecret.IsNull() {
+		resp.Diagnostics.AddError("auth_key and auth_secret must be set together",
+			fmt.Sprintf("Checked the provider attributes and the %s, %s environment variables", authKeyEnvVar, authSecretEnvVar))
+		return
+	}
+
+	httpClient, err := createConductorHttpClient(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create Conductor client", err.Error())
+		return
+	}
+	p.client = conductorapi.NewClient(httpClient)
 
 	resp.DataSourceData = p // will be usable by DataSources
 	resp.ResourceData = p   // will be usable by Resources
 }
 
+// applyEnvDefaults fills null provider attributes from environment variables.
+func applyEnvDefaults(ctx context.Context, data *ConductorProviderModel, diagnostics *diag.Diagnostics) {
+	if envValue := os.Getenv(endpointEnvVar); data.Endpoint.IsNull() && envValue != "" {
+		data.Endpoint = tftypes.StringValue(envValue)
+	}
+
+	if envValue := os.Getenv(authKeyEnvVar); data.AuthKey.IsNull() && envValue != "" {
+		data.AuthKey = tftypes.StringValue(envValue)
+	}
+
+	if envValue := os.Getenv(authSecretEnvVar); data.AuthSecret.IsNull() && envValue != "" {
+		data.AuthSecret = tftypes.StringValue(envValue)
+	}
+
+	if data.CustomHeaders.IsUnknown() {
+		return
+	}
+
+	headers := make(map[string]string)
+	for _, env := range os.Environ() {
+		key, value, found := strings.Cut(env, "=")
+		if !found || !strings.HasPrefix(key, headerEnvVarPrefix) {
+			continue
+		}
+
+		headerName := strings.ReplaceAll(strings.TrimPrefix(key, headerEnvVarPrefix), "_", "-")
+		if headerName == "" {
+			continue
+		}
+		headers[headerName] = value
+	}
+
+	if len(headers) == 0 {
+		return
+	}
+
+	for key, value := range data.CustomHeaders.Elements() {
+		stringVal, ok := value.(tftypes.String)
+		if ok && !stringVal.IsNull() && !stringVal.IsUnknown() {
+			headers[key] = stringVal.ValueString()
+		}
+	}
+
+	mergedHeaders, mapDiags := tftypes.MapValueFrom(ctx, tftypes.StringType, headers)
+	diagnostics.Append(mapDiags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	data.CustomHeaders = mergedHeaders
+}
+
 func (p *ConductorProvider) Resources(ctx context.Context) []func() tfresource.Resource {
 	return []func() tfresource.Resource{
 		NewTaskDefResource,
 		NewWorkflowDefResource,
+		NewEventHandlerResource,
 	}
 }
 
 func (p *ConductorProvider) DataSources(ctx context.Context) []func() tfdatasource.DataSource {
-	return []func() tfdatasource.DataSource{}
+	return []func() tfdatasource.DataSource{
+		NewTaskDefDataSource,
+		NewWorkflowDefDataSource,
+		NewTaskDefsDataSource,
+		NewWorkflowDefVersionsDataSource,
+	}
 }
 
 func (p *ConductorProvider) Functions(ctx context.Context) []func() tffunction.Function {
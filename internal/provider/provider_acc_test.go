@@ -0,0 +1,36 @@
+//go:build acceptance
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorfake"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// testAccProtoV6ProviderFactories are used to instantiate the provider during acceptance testing.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"conductor": providerserver.NewProtocol6WithError(New()()),
+}
+
+// testAccFakeServer starts a fake Conductor server for the test and returns it with the provider configuration
+// pointing at it, so the acceptance tests run without a real Conductor.
+func testAccFakeServer(t *testing.T) (*conductorfake.Server, string) {
+	t.Helper()
+
+	fake := conductorfake.New()
+	server := fake.Start()
+	t.Cleanup(server.Close)
+
+	providerConfig := fmt.Sprintf(`
+provider "conductor" {
+  endpoint    = "%s/api"
+  max_retries = 0
+}
+`, server.URL)
+
+	return fake, providerConfig
+}
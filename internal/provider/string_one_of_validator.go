@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type stringOneOfValidator struct {
+	values []string
+}
+
+func (m stringOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("Value must be one of: %s", strings.Join(m.values, ", "))
+}
+
+func (m stringOneOfValidator) MarkdownDescription(c context.Context) string {
+	return m.Description(c)
+}
+
+func (m stringOneOfValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(m.values, req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid value",
+			fmt.Sprintf("Value must be one of: %s, got: %s", strings.Join(m.values, ", "), req.ConfigValue.ValueString()))
+	}
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorapi"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	tfdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	tfdsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ tfdatasource.DataSource = &TaskDefDataSource{}
+var _ tfdatasource.DataSourceWithConfigure = &TaskDefDataSource{}
+
+type TaskDefDataSource struct {
+	client *conductorapi.Client
+}
+
+type TaskDefDataSourceModel struct {
+	Name                        tftypes.String       `tfsdk:"name"`
+	Manifest                    jsontypes.Normalized `tfsdk:"manifest"`
+	Description                 tftypes.String       `tfsdk:"description"`
+	OwnerEmail                  tftypes.String       `tfsdk:"owner_email"`
+	RetryCount                  tftypes.Int32        `tfsdk:"retry_count"`
+	RetryLogic                  tftypes.String       `tfsdk:"retry_logic"`
+	RetryDelaySeconds           tftypes.Int32        `tfsdk:"retry_delay_seconds"`
+	BackoffScaleFactor          tftypes.Int32        `tfsdk:"backoff_scale_factor"`
+	TimeoutSeconds              tftypes.Int64        `tfsdk:"timeout_seconds"`
+	TimeoutPolicy               tftypes.String       `tfsdk:"timeout_policy"`
+	ResponseTimeoutSeconds      tftypes.Int64        `tfsdk:"response_timeout_seconds"`
+	PollTimeoutSeconds          tftypes.Int32        `tfsdk:"poll_timeout_seconds"`
+	ConcurrentExecLimit         tftypes.Int32        `tfsdk:"concurrent_exec_limit"`
+	RateLimitPerFrequency       tftypes.Int32        `tfsdk:"rate_limit_per_frequency"`
+	RateLimitFrequencyInSeconds tftypes.Int32        `tfsdk:"rate_limit_frequency_in_seconds"`
+	InputKeys                   tftypes.List         `tfsdk:"input_keys"`
+	OutputKeys                  tftypes.List         `tfsdk:"output_keys"`
+}
+
+func NewTaskDefDataSource() tfdatasource.DataSource {
+	return &TaskDefDataSource{}
+}
+
+func (d *TaskDefDataSource) Metadata(ctx context.Context, req tfdatasource.MetadataRequest, resp *tfdatasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_taskdef"
+}
+
+func (d *TaskDefDataSource) Schema(ctx context.Context, req tfdatasource.SchemaRequest, resp *tfdatasource.SchemaResponse) {
+	resp.Schema = tfdsschema.Schema{
+		Description:         "Conductor Task Definition lookup by name",
+		MarkdownDescription: "Conductor Task Definition lookup by name",
+		Attributes: map[string]tfdsschema.Attribute{
+			"name": tfdsschema.StringAttribute{
+				Description: "The task definition name",
+				Required:    true,
+			},
+			"manifest": tfdsschema.StringAttribute{
+				Description: "The JSON Manifest of the task definition as returned by Conductor",
+				Computed:    true,
+				CustomType:  jsontypes.NormalizedType{},
+			},
+			"description":                     tfdsschema.StringAttribute{Computed: true},
+			"owner_email":                     tfdsschema.StringAttribute{Computed: true},
+			"retry_count":                     tfdsschema.Int32Attribute{Computed: true},
+			"retry_logic":                     tfdsschema.StringAttribute{Computed: true},
+			"retry_delay_seconds":             tfdsschema.Int32Attribute{Computed: true},
+			"backoff_scale_factor":            tfdsschema.Int32Attribute{Computed: true},
+			"timeout_seconds":                 tfdsschema.Int64Attribute{Computed: true},
+			"timeout_policy":                  tfdsschema.StringAttribute{Computed: true},
+			"response_timeout_seconds":        tfdsschema.Int64Attribute{Computed: true},
+			"poll_timeout_seconds":            tfdsschema.Int32Attribute{Computed: true},
+			"concurrent_exec_limit":           tfdsschema.Int32Attribute{Computed: true},
+			"rate_limit_per_frequency":        tfdsschema.Int32Attribute{Computed: true},
+			"rate_limit_frequency_in_seconds": tfdsschema.Int32Attribute{Computed: true},
+			"input_keys": tfdsschema.ListAttribute{
+				Computed:    true,
+				ElementType: tftypes.StringType,
+			},
+			"output_keys": tfdsschema.ListAttribute{
+				Computed:    true,
+				ElementType: tftypes.StringType,
+			},
+		},
+	}
+}
+
+func (d *TaskDefDataSource) Configure(ctx context.Context, req tfdatasource.ConfigureRequest, resp *tfdatasource.ConfigureResponse) {
+	if req.ProviderData == nil { // this means the provider.go Configure method hasn't been called yet, so wait longer
+		return
+	}
+	provider, ok := req.ProviderData.(*ConductorProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Could not create Conductor Provider",
+			fmt.Sprintf("Expected *ConductorProvider, got: %T", req.ProviderData),
+		)
+		return
+	}
+	d.client = provider.client
+}
+
+func (d *TaskDefDataSource) Read(ctx context.Context, req tfdatasource.ReadRequest, resp *tfdatasource.ReadResponse) {
+	var data TaskDefDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	manifestMap, err := d.client.GetTaskDefManifest(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read task def %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	var taskDef conductorapi.TaskDef
+	err = conductorapi.DecodeManifest(manifestMap, &taskDef)
+	if err != nil {
+		resp.Diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Unexpected task def manifest: %s", err))
+		return
+	}
+
+	manifestBytes, err := json.Marshal(manifestMap)
+	if err != nil {
+		resp.Diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Manifest must be a valid json: %s", err))
+		return
+	}
+
+	data.Manifest = jsontypes.NewNormalizedValue(string(manifestBytes))
+	data.Description = tftypes.StringValue(taskDef.Description)
+	data.OwnerEmail = tftypes.StringValue(taskDef.OwnerEmail)
+	data.RetryCount = tftypes.Int32Value(taskDef.RetryCount)
+	data.RetryLogic = tftypes.StringValue(taskDef.RetryLogic)
+	data.RetryDelaySeconds = tftypes.Int32Value(taskDef.RetryDelaySeconds)
+	data.BackoffScaleFactor = tftypes.Int32PointerValue(taskDef.BackoffScaleFactor)
+	data.TimeoutSeconds = tftypes.Int64Value(taskDef.TimeoutSeconds)
+	data.TimeoutPolicy = tftypes.StringValue(taskDef.TimeoutPolicy)
+	data.ResponseTimeoutSeconds = tftypes.Int64Value(taskDef.ResponseTimeoutSeconds)
+	data.PollTimeoutSeconds = tftypes.Int32PointerValue(taskDef.PollTimeoutSeconds)
+	data.ConcurrentExecLimit = tftypes.Int32PointerValue(taskDef.ConcurrentExecLimit)
+	data.RateLimitPerFrequency = tftypes.Int32PointerValue(taskDef.RateLimitPerFrequency)
+	data.RateLimitFrequencyInSeconds = tftypes.Int32PointerValue(taskDef.RateLimitFrequencyInSeconds)
+
+	inputKeys, diags := tftypes.ListValueFrom(ctx, tftypes.StringType, nonNilStrings(taskDef.InputKeys))
+	resp.Diagnostics.Append(diags...)
+	outputKeys, diags := tftypes.ListValueFrom(ctx, tftypes.StringType, nonNilStrings(taskDef.OutputKeys))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.InputKeys = inputKeys
+	data.OutputKeys = outputKeys
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
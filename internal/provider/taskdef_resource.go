@@ -1,21 +1,21 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"reflect"
+	"strings"
 
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorapi"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	tfschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var auditableFieldsToIgnore = [4]string{"createTime", "updateTime", "createdBy", "updatedBy"}
@@ -35,11 +35,15 @@ var _ tfresource.ResourceWithImportState = &TaskDefResource{}
 var _ tfresource.ResourceWithModifyPlan = &TaskDefResource{}
 
 type TaskDefResource struct {
-	client *conductorHttpClient
+	client *conductorapi.Client
 }
 
 type TaskDefModel struct {
-	Manifest jsontypes.Normalized `tfsdk:"manifest"`
+	Manifest          ConductorManifest    `tfsdk:"manifest"`
+	EffectiveManifest jsontypes.Normalized `tfsdk:"effective_manifest"`
+	ForceDestroy      tftypes.Bool         `tfsdk:"force_destroy"`
+	Timeouts          timeouts.Value       `tfsdk:"timeouts"`
+	auditModel
 }
 
 func NewTaskDefResource() tfresource.Resource {
@@ -58,7 +62,7 @@ func (r *TaskDefResource) Schema(ctx context.Context, req tfresource.SchemaReque
 			"manifest": tfschema.StringAttribute{
 				Description: "The JSON Manifest for the task definition",
 				Required:    true,
-				CustomType:  jsontypes.NormalizedType{},
+				CustomType:  taskDefManifestType,
 				PlanModifiers: []planmodifier.String{
 					nameChangedModifier{},
 				},
@@ -66,6 +70,19 @@ func (r *TaskDefResource) Schema(ctx context.Context, req tfresource.SchemaReque
 					manifestNameValidator{},
 				},
 			},
+			"effective_manifest": effectiveManifestAttribute(),
+			"create_time":        auditTimeAttribute("Creation time in epoch milliseconds"),
+			"update_time":        auditTimeAttribute("Last update time in epoch milliseconds"),
+			"created_by":         auditUserAttribute("The user that created the task definition"),
+			"updated_by":         auditUserAttribute("The user that last updated the task definition"),
+			"force_destroy": tfschema.BoolAttribute{
+				MarkdownDescription: "Delete the task definition even when workflow definitions still reference it. " +
+					"The value must be applied before the destroy. Defaults to false",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]tfschema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
@@ -96,7 +113,12 @@ func (r *TaskDefResource) ModifyPlan(ctx context.Context, req tfresource.ModifyP
 		return
 	}
 
-	if plan.Manifest.IsNull() || plan.Manifest.IsUnknown() {
+	if plan.Manifest.IsNull() {
+		return
+	}
+
+	if plan.Manifest.IsUnknown() {
+		resp.Diagnostics.Append(setAuditUnknown(ctx, &resp.Plan)...)
 		return
 	}
 
@@ -110,24 +132,19 @@ func (r *TaskDefResource) ModifyPlan(ctx context.Context, req tfresource.ModifyP
 		return
 	}
 
-	var planDef map[string]interface{}
-	err := json.Unmarshal([]byte(plan.Manifest.ValueString()), &planDef)
-	if err != nil {
+	equal, diags := plan.Manifest.StringSemanticEquals(ctx, state.Manifest)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var stateDef map[string]interface{}
-	err = json.Unmarshal([]byte(state.Manifest.ValueString()), &stateDef)
-	if err != nil {
+	if equal {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("manifest"), state.Manifest)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_manifest"), state.EffectiveManifest)...)
 		return
 	}
 
-	cleanupManifestDefaults(ctx, planDef, defaultTaskDefValues)
-	cleanupManifestDefaults(ctx, stateDef, defaultTaskDefValues)
-
-	if reflect.DeepEqual(planDef, stateDef) {
-		resp.Diagnostics.Append(resp.Plan.Set(ctx, &state)...)
-	}
+	resp.Diagnostics.Append(setAuditUnknown(ctx, &resp.Plan)...)
 }
 
 func (r *TaskDefResource) Create(ctx context.Context, req tfresource.CreateRequest, resp *tfresource.CreateResponse) {
@@ -137,6 +154,15 @@ func (r *TaskDefResource) Create(ctx context.Context, req tfresource.CreateReque
 		return
 	}
 
+	createTimeout, diags := state.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var manifestMap map[string]interface{}
 
 	resp.Diagnostics.Append(state.Manifest.Unmarshal(&manifestMap)...)
@@ -149,38 +175,20 @@ func (r *TaskDefResource) Create(ctx context.Context, req tfresource.CreateReque
 		delete(manifestMap, f)
 	}
 
-	//des manifestBack
-	var requestBody [1]map[string]interface{}
-	requestBody[0] = manifestMap
-
-	requestBytes, err := json.Marshal(requestBody)
+	err := r.client.CreateTaskDefs(ctx, manifestMap)
 	if err != nil {
-		resp.Diagnostics.AddError("Invalid Manifest", fmt.Sprintf("Manifest Marshal error: %s", err))
+		addManifestErrorDiagnostics(&resp.Diagnostics, "Unable to create task def", err)
 		return
 	}
 
-	response, err := r.client.do(ctx, http.MethodPost, "metadata/taskdefs", bytes.NewBuffer(requestBytes))
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error sending request: %s", err))
-		return
-	}
-	defer response.Body.Close()
-
-	body, bodyErr := io.ReadAll(response.Body)
-
-	if response.StatusCode != http.StatusOK {
-		if bodyErr != nil {
-			resp.Diagnostics.AddError("HTTP Error", fmt.Sprintf("Received non-OK HTTP status: %s. Failed to read response body: %s",
-				response.Status, bodyErr))
-			return
-		}
-
-		resp.Diagnostics.AddError("HTTP Error", fmt.Sprintf("Received non-OK HTTP status: %s. Body: %s", response.Status, string(body)))
+	currentManifestMap := r.readCurrentManifest(ctx, manifestMap, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if bodyErr != nil {
-		resp.Diagnostics.AddError("Status was OK but failed to Read Response Body", fmt.Sprintf("Could not read response body: %s", err))
+	state.EffectiveManifest = effectiveManifestValue(currentManifestMap, &resp.Diagnostics)
+	state.auditModel = newAuditModel(currentManifestMap, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -194,50 +202,41 @@ func (r *TaskDefResource) Read(ctx context.Context, req tfresource.ReadRequest,
 		return
 	}
 
-	var stateManifestMap map[string]interface{}
-
-	resp.Diagnostics.Append(state.Manifest.Unmarshal(&stateManifestMap)...)
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	stateTaskType := getTaskTypeFromManifest(stateManifestMap, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	path := fmt.Sprintf("metadata/taskdefs/%s", stateTaskType)
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	response, err := r.client.do(ctx, http.MethodGet, path, nil)
+	var stateManifestMap map[string]interface{}
 
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read task, got error: %s", err))
+	resp.Diagnostics.Append(state.Manifest.Unmarshal(&stateManifestMap)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	defer response.Body.Close()
-
-	if response.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
+	stateTaskType := getManifestStringValue(stateManifestMap, "name", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if response.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError(fmt.Sprintf("HTTP Error path: %s", path), fmt.Sprintf("Received bad HTTP status: %s", response.Status))
+	currentManifestMap, err := r.client.GetTaskDefManifest(ctx, stateTaskType)
+	if conductorapi.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	bodyBytes, err := io.ReadAll(response.Body)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading response body", err.Error())
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read task, got error: %s", err))
 		return
 	}
 
-	var currentManifestMap map[string]interface{}
-
-	err = json.Unmarshal(bodyBytes, &currentManifestMap)
-	if err != nil {
-		resp.Diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Manifest must be a valid json: %s", err))
+	state.EffectiveManifest = effectiveManifestValue(currentManifestMap, &resp.Diagnostics)
+	state.auditModel = newAuditModel(currentManifestMap, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -257,7 +256,7 @@ func (r *TaskDefResource) Read(ctx context.Context, req tfresource.ReadRequest,
 		return
 	}
 
-	state.Manifest = jsontypes.NewNormalizedValue(string(updatedStateBytes))
+	state.Manifest = NewConductorManifestValue(taskDefManifestType, string(updatedStateBytes))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -269,55 +268,48 @@ func (r *TaskDefResource) Delete(ctx context.Context, req tfresource.DeleteReque
 		return
 	}
 
-	var manifestMap map[string]interface{}
-
-	resp.Diagnostics.Append(state.Manifest.Unmarshal(&manifestMap)...)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	taskType := getTaskTypeFromManifest(manifestMap, &resp.Diagnostics)
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	var manifestMap map[string]interface{}
+
+	resp.Diagnostics.Append(state.Manifest.Unmarshal(&manifestMap)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	path := fmt.Sprintf("metadata/taskdefs/%s", taskType)
-
-	response, err := r.client.do(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Delete Error", fmt.Sprintf("Unable to delete task def, got error: %s", err))
+	taskType := getManifestStringValue(manifestMap, "name", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	defer response.Body.Close()
-	alreadyDeleted := false
-
-	if response.StatusCode != http.StatusOK {
-		if response.StatusCode == http.StatusInternalServerError {
-			//check if exists
-			internalGetResponse, err := r.client.do(ctx, http.MethodGet, path, nil)
-			if err == nil && internalGetResponse.StatusCode == http.StatusNotFound {
-				alreadyDeleted = true
-			}
-			if err == nil {
-				defer internalGetResponse.Body.Close()
-			}
+	if !state.ForceDestroy.ValueBool() {
+		references, err := findTaskDefReferences(ctx, r.client, taskType)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow defs, got error: %s", err))
+			return
 		}
 
-		if !alreadyDeleted {
-			bodyBytes, err := io.ReadAll(response.Body)
-			var bodyStr string
-			if err == nil {
-				bodyStr = string(bodyBytes)
-			} else {
-				bodyStr = fmt.Sprintf("Read All Body Error: %s", err)
-			}
-
-			resp.Diagnostics.AddError("HTTP Error", fmt.Sprintf("Received non-OK HTTP status: %s. Body: %s", response.Status, bodyStr))
+		if len(references) > 0 {
+			resp.Diagnostics.AddError("Task def is referenced by workflow defs",
+				fmt.Sprintf("Task def %s can't be deleted while workflow defs reference it, set force_destroy to delete anyway. Referencing workflow defs:\n%s",
+					taskType, strings.Join(references, "\n")))
 			return
 		}
 	}
 
+	err := r.client.DeleteTaskDef(ctx, taskType)
+	if err != nil && !conductorapi.IsNotFound(err) {
+		resp.Diagnostics.AddError("Delete Error", fmt.Sprintf("Unable to delete task def, got error: %s", err))
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -328,6 +320,35 @@ func (r *TaskDefResource) Update(ctx context.Context, req tfresource.UpdateReque
 		return
 	}
 
+	updateTimeout, diags := state.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var priorState TaskDefModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	//only non manifest attributes (e.g. force_destroy) changed, nothing to update on the server
+	manifestEqual, diags := state.Manifest.StringSemanticEquals(ctx, priorState.Manifest)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if manifestEqual {
+		state.EffectiveManifest = priorState.EffectiveManifest
+		state.auditModel = priorState.auditModel
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
 	var manifestMap map[string]interface{}
 	resp.Diagnostics.Append(state.Manifest.Unmarshal(&manifestMap)...)
 	if resp.Diagnostics.HasError() {
@@ -339,32 +360,25 @@ func (r *TaskDefResource) Update(ctx context.Context, req tfresource.UpdateReque
 		delete(manifestMap, f)
 	}
 
-	putBodyBytes, err := json.Marshal(manifestMap)
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid Manifest", fmt.Sprintf("Manifest Marshal error: %s", err))
+	err := r.client.UpdateTaskDef(ctx, manifestMap)
+	if conductorapi.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	response, err := r.client.do(ctx, http.MethodPut, "metadata/taskdefs", bytes.NewBuffer(putBodyBytes))
-
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error sending request: %s", err))
+		addManifestErrorDiagnostics(&resp.Diagnostics, "Unable to update task def", err)
 		return
 	}
-	defer response.Body.Close()
 
-	if response.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
+	currentManifestMap := r.readCurrentManifest(ctx, manifestMap, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if response.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(response.Body)
-		if err != nil {
-			resp.Diagnostics.AddError("Failed to Read Response Body", fmt.Sprintf("Received non-OK HTTP status: %s, Could not read response body: %s", response.Status, err))
-			return
-		}
-		resp.Diagnostics.AddError("HTTP Error", fmt.Sprintf("Received non-OK HTTP status: %s, Body: %s", response.Status, string(body)))
+	state.EffectiveManifest = effectiveManifestValue(currentManifestMap, &resp.Diagnostics)
+	state.auditModel = newAuditModel(currentManifestMap, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -386,23 +400,53 @@ func (r *TaskDefResource) ImportState(ctx context.Context, req tfresource.Import
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("manifest"), string(manifestBytes))...)
 }
 
-func getTaskTypeFromManifest(manifestMap map[string]interface{}, diagnostics *diag.Diagnostics) string {
-	taskTypeVal, ok := manifestMap["name"]
-	if !ok {
-		diagnostics.AddError("Invalid Manifest", "'name' parameter is missing from manifest")
-		return ""
+// readCurrentManifest reads the task def as stored by the server after create / update.
+func (r *TaskDefResource) readCurrentManifest(ctx context.Context, manifestMap map[string]interface{}, diagnostics *diag.Diagnostics) map[string]interface{} {
+	taskType := getManifestStringValue(manifestMap, "name", diagnostics)
+	if diagnostics.HasError() {
+		return nil
 	}
 
-	taskType, ok := taskTypeVal.(string)
-	if !ok || taskType == "" {
-		diagnostics.AddError("Invalid Manifest", "'name' parameter must be string")
-		return ""
+	currentManifestMap, err := r.client.GetTaskDefManifest(ctx, taskType)
+	if err != nil {
+		diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read task def %s, got error: %s", taskType, err))
+		return nil
 	}
 
-	return taskType
+	return currentManifestMap
 }
 
 func taskDefCleanupAndMerge(ctx context.Context, currentManifestMap map[string]interface{}, stateManifestMap map[string]interface{}) {
 	cleanupManifestDefaults(ctx, currentManifestMap, defaultTaskDefValues)
-	mergeManifestMaps(ctx, currentManifestMap, stateManifestMap)
+	mergeManifestMaps(ctx, currentManifestMap, stateManifestMap, defaultTaskDefValues)
+}
+
+// findTaskDefReferences returns the workflow def versions with a SIMPLE task named after the task def,
+// nested tasks and inline sub workflow definitions included. e.g. - "my_workflow version 2".
+func findTaskDefReferences(ctx context.Context, client *conductorapi.Client, taskType string) ([]string, error) {
+	workflowDefs, err := client.GetWorkflowDefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var references []string
+	for _, workflowDef := range workflowDefs {
+		if workflowDefReferencesTask(workflowDef.Tasks, taskType) {
+			references = append(references, fmt.Sprintf("%s version %d", workflowDef.Name, workflowDef.Version))
+		}
+	}
+
+	return references, nil
+}
+
+func workflowDefReferencesTask(tasks []conductorapi.WorkflowTask, taskType string) bool {
+	found := false
+
+	conductorapi.WalkWorkflowTasks(tasks, func(task conductorapi.WorkflowTask) {
+		if task.IsSimple() && task.Name == taskType {
+			found = true
+		}
+	})
+
+	return found
 }
@@ -0,0 +1,88 @@
+//go:build acceptance
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func testAccTaskDefConfig(providerConfig string, retryCount int) string {
+	return providerConfig + fmt.Sprintf(`
+resource "conductor_taskdef" "test" {
+  manifest = jsonencode({
+    name       = "acc_task"
+    ownerEmail = "owner@example.com"
+    retryCount = %d
+  })
+}
+`, retryCount)
+}
+
+func TestAccTaskDefResource(t *testing.T) {
+	fake, providerConfig := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read
+			{
+				Config: testAccTaskDefConfig(providerConfig, 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("conductor_taskdef.test", "manifest", `{"name":"acc_task","ownerEmail":"owner@example.com","retryCount":5}`),
+					resource.TestMatchResourceAttr("conductor_taskdef.test", "effective_manifest", regexp.MustCompile(`"timeoutPolicy":"TIME_OUT_WF"`)),
+					resource.TestCheckResourceAttrSet("conductor_taskdef.test", "create_time"),
+				),
+			},
+			// Update and Read
+			{
+				Config: testAccTaskDefConfig(providerConfig, 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("conductor_taskdef.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("conductor_taskdef.test", "manifest", `{"name":"acc_task","ownerEmail":"owner@example.com","retryCount":2}`),
+					resource.TestMatchResourceAttr("conductor_taskdef.test", "effective_manifest", regexp.MustCompile(`"retryCount":2`)),
+					resource.TestCheckResourceAttrSet("conductor_taskdef.test", "update_time"),
+				),
+			},
+			// ImportState
+			{
+				ResourceName:                         "conductor_taskdef.test",
+				ImportState:                          true,
+				ImportStateId:                        "acc_task",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "manifest",
+			},
+			// Drift: changed remotely, the plan restores the configured manifest
+			{
+				PreConfig: func() {
+					edited := fake.EditTaskDef("acc_task", func(taskDef map[string]interface{}) {
+						taskDef["retryCount"] = float64(7)
+						delete(taskDef, "ownerEmail")
+					})
+					if !edited {
+						t.Fatal("task def acc_task not found in the fake server")
+					}
+				},
+				Config: testAccTaskDefConfig(providerConfig, 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("conductor_taskdef.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("conductor_taskdef.test", "manifest", `{"name":"acc_task","ownerEmail":"owner@example.com","retryCount":2}`),
+					resource.TestMatchResourceAttr("conductor_taskdef.test", "effective_manifest", regexp.MustCompile(`"ownerEmail":"owner@example.com"`)),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
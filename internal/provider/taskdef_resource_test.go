@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorapi"
+)
+
+func TestWorkflowDefReferencesTask(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    string
+		expected bool
+	}{
+		{
+			name:     "simple task",
+			tasks:    `[{"name":"task","taskReferenceName":"task_ref","type":"SIMPLE"}]`,
+			expected: true,
+		},
+		{
+			name:     "task without type",
+			tasks:    `[{"name":"task","taskReferenceName":"task_ref"}]`,
+			expected: true,
+		},
+		{
+			name:     "system task with the same name",
+			tasks:    `[{"name":"task","taskReferenceName":"task_ref","type":"HTTP"},{"name":"task","taskReferenceName":"sub_ref","type":"SUB_WORKFLOW"}]`,
+			expected: false,
+		},
+		{
+			name:     "other task",
+			tasks:    `[{"name":"other","taskReferenceName":"other_ref"}]`,
+			expected: false,
+		},
+		{
+			name:     "nested simple task",
+			tasks:    `[{"name":"switch","taskReferenceName":"switch_ref","type":"SWITCH","decisionCases":{"case1":[{"name":"task","taskReferenceName":"task_ref"}]}}]`,
+			expected: true,
+		},
+		{
+			name: "inline sub workflow simple task",
+			tasks: `[{"name":"sub","taskReferenceName":"sub_ref","type":"SUB_WORKFLOW","subWorkflowParam":{"name":"inline","workflowDefinition":{
+				"name":"inline","tasks":[{"name":"task","taskReferenceName":"task_ref","type":"SIMPLE"}]}}}]`,
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tasks := unmarshalTestJSON[[]conductorapi.WorkflowTask](t, test.tasks)
+			if actual := workflowDefReferencesTask(tasks, "task"); actual != test.expected {
+				t.Errorf("workflowDefReferencesTask = %t, expected %t", actual, test.expected)
+			}
+		})
+	}
+}
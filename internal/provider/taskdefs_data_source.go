@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorapi"
+	tfdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	tfdsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ tfdatasource.DataSource = &TaskDefsDataSource{}
+var _ tfdatasource.DataSourceWithConfigure = &TaskDefsDataSource{}
+
+type TaskDefsDataSource struct {
+	client *conductorapi.Client
+}
+
+type TaskDefsDataSourceModel struct {
+	NameRegex    tftypes.String `tfsdk:"name_regex"`
+	NamePrefix   tftypes.String `tfsdk:"name_prefix"`
+	OwnerEmail   tftypes.String `tfsdk:"owner_email"`
+	HasRateLimit tftypes.Bool   `tfsdk:"has_rate_limit"`
+	Names        tftypes.List   `tfsdk:"names"`
+	Manifests    tftypes.Map    `tfsdk:"manifests"`
+}
+
+func NewTaskDefsDataSource() tfdatasource.DataSource {
+	return &TaskDefsDataSource{}
+}
+
+func (d *TaskDefsDataSource) Metadata(ctx context.Context, req tfdatasource.MetadataRequest, resp *tfdatasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_taskdefs"
+}
+
+func (d *TaskDefsDataSource) Schema(ctx context.Context, req tfdatasource.SchemaRequest, resp *tfdatasource.SchemaResponse) {
+	resp.Schema = tfdsschema.Schema{
+		Description:         "List of Conductor Task Definitions",
+		MarkdownDescription: "List of Conductor Task Definitions, all the filters are optional and combined with AND",
+		Attributes: map[string]tfdsschema.Attribute{
+			"name_regex": tfdsschema.StringAttribute{
+				Description: "Only task definitions with a name matching the regular expression",
+				Optional:    true,
+			},
+			"name_prefix": tfdsschema.StringAttribute{
+				Description: "Only task definitions with a name starting with the prefix",
+				Optional:    true,
+			},
+			"owner_email": tfdsschema.StringAttribute{
+				Description: "Only task definitions with the owner email",
+				Optional:    true,
+			},
+			"has_rate_limit": tfdsschema.BoolAttribute{
+				Description: "true - only task definitions with a rate limit (rateLimitPerFrequency > 0), false - only task definitions without a rate limit",
+				Optional:    true,
+			},
+			"names": tfdsschema.ListAttribute{
+				Description: "The matching task definition names, sorted",
+				Computed:    true,
+				ElementType: tftypes.StringType,
+			},
+			"manifests": tfdsschema.MapAttribute{
+				Description: "The JSON Manifests of the matching task definitions by name",
+				Computed:    true,
+				ElementType: tftypes.StringType,
+			},
+		},
+	}
+}
+
+func (d *TaskDefsDataSource) Configure(ctx context.Context, req tfdatasource.ConfigureRequest, resp *tfdatasource.ConfigureResponse) {
+	if req.ProviderData == nil { // this means the provider.go Configure method hasn't been called yet, so wait longer
+		return
+	}
+	provider, ok := req.ProviderData.(*ConductorProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Could not create Conductor Provider",
+			fmt.Sprintf("Expected *ConductorProvider, got: %T", req.ProviderData),
+		)
+		return
+	}
+	d.client = provider.client
+}
+
+func (d *TaskDefsDataSource) Read(ctx context.Context, req tfdatasource.ReadRequest, resp *tfdatasource.ReadResponse) {
+	var data TaskDefsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+			return
+		}
+	}
+
+	manifests, err := d.client.GetTaskDefManifests(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list task defs, got error: %s", err))
+		return
+	}
+
+	names := []string{}
+	manifestsByName := make(map[string]string)
+
+	for _, manifestMap := range manifests {
+		var taskDef conductorapi.TaskDef
+		err = conductorapi.DecodeManifest(manifestMap, &taskDef)
+		if err != nil {
+			resp.Diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Unexpected task def manifest: %s", err))
+			return
+		}
+
+		if nameRegex != nil && !nameRegex.MatchString(taskDef.Name) {
+			continue
+		}
+
+		if !data.NamePrefix.IsNull() && !strings.HasPrefix(taskDef.Name, data.NamePrefix.ValueString()) {
+			continue
+		}
+
+		if !data.OwnerEmail.IsNull() && taskDef.OwnerEmail != data.OwnerEmail.ValueString() {
+			continue
+		}
+
+		if !data.HasRateLimit.IsNull() {
+			hasRateLimit := taskDef.RateLimitPerFrequency != nil && *taskDef.RateLimitPerFrequency > 0
+			if hasRateLimit != data.HasRateLimit.ValueBool() {
+				continue
+			}
+		}
+
+		manifestBytes, err := json.Marshal(manifestMap)
+		if err != nil {
+			resp.Diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Manifest must be a valid json: %s", err))
+			return
+		}
+
+		names = append(names, taskDef.Name)
+		manifestsByName[taskDef.Name] = string(manifestBytes)
+	}
+
+	slices.Sort(names)
+
+	namesList, diags := tftypes.ListValueFrom(ctx, tftypes.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	manifestsMap, diags := tftypes.MapValueFrom(ctx, tftypes.StringType, manifestsByName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Names = namesList
+	data.Manifests = manifestsMap
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	tfschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// defaultOperationTimeout is used for every operation without a configured timeout, a hung Conductor call is aborted once reached.
+const defaultOperationTimeout = 20 * time.Minute
+
+func timeoutsBlock(ctx context.Context) tfschema.Block {
+	operationDescription := func(operation string) string {
+		return fmt.Sprintf("Timeout of the %s operation as a duration string, e.g. - 30s, 10m. Defaults to %s",
+			operation, defaultOperationTimeout)
+	}
+
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: operationDescription("create"),
+		ReadDescription:   operationDescription("read"),
+		UpdateDescription: operationDescription("update"),
+		DeleteDescription: operationDescription("delete"),
+	})
+}
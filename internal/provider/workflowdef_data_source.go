@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorapi"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	tfdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	tfdsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ tfdatasource.DataSource = &WorkflowDefDataSource{}
+var _ tfdatasource.DataSourceWithConfigure = &WorkflowDefDataSource{}
+
+type WorkflowDefDataSource struct {
+	client *conductorapi.Client
+}
+
+type WorkflowDefDataSourceModel struct {
+	Name               tftypes.String       `tfsdk:"name"`
+	Version            tftypes.Int32        `tfsdk:"version"`
+	Manifest           jsontypes.Normalized `tfsdk:"manifest"`
+	TaskReferenceNames tftypes.List         `tfsdk:"task_reference_names"`
+	TaskDefNames       tftypes.List         `tfsdk:"task_def_names"`
+}
+
+func NewWorkflowDefDataSource() tfdatasource.DataSource {
+	return &WorkflowDefDataSource{}
+}
+
+func (d *WorkflowDefDataSource) Metadata(ctx context.Context, req tfdatasource.MetadataRequest, resp *tfdatasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflowdef"
+}
+
+func (d *WorkflowDefDataSource) Schema(ctx context.Context, req tfdatasource.SchemaRequest, resp *tfdatasource.SchemaResponse) {
+	resp.Schema = tfdsschema.Schema{
+		Description:         "Conductor Workflow Definition lookup by name and optional version",
+		MarkdownDescription: "Conductor Workflow Definition lookup by name and optional version, the latest version is used when version isn't set",
+		Attributes: map[string]tfdsschema.Attribute{
+			"name": tfdsschema.StringAttribute{
+				Description: "The workflow definition name",
+				Required:    true,
+			},
+			"version": tfdsschema.Int32Attribute{
+				Description: "The workflow definition version, defaults to the latest version",
+				Optional:    true,
+				Computed:    true,
+			},
+			"manifest": tfdsschema.StringAttribute{
+				Description: "The JSON Manifest of the workflow definition, without server default values",
+				Computed:    true,
+				CustomType:  jsontypes.NormalizedType{},
+			},
+			"task_reference_names": tfdsschema.ListAttribute{
+				Description: "The reference names of all the workflow tasks, including nested tasks and the tasks of inline sub workflow definitions",
+				Computed:    true,
+				ElementType: tftypes.StringType,
+			},
+			"task_def_names": tfdsschema.ListAttribute{
+				Description: "The task definition names referenced by SIMPLE tasks, including nested tasks and the tasks of inline sub workflow definitions",
+				Computed:    true,
+				ElementType: tftypes.StringType,
+			},
+		},
+	}
+}
+
+func (d *WorkflowDefDataSource) Configure(ctx context.Context, req tfdatasource.ConfigureRequest, resp *tfdatasource.ConfigureResponse) {
+	if req.ProviderData == nil { // this means the provider.go Configure method hasn't been called yet, so wait longer
+		return
+	}
+	provider, ok := req.ProviderData.(*ConductorProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Could not create Conductor Provider",
+			fmt.Sprintf("Expected *ConductorProvider, got: %T", req.ProviderData),
+		)
+		return
+	}
+	d.client = provider.client
+}
+
+func (d *WorkflowDefDataSource) Read(ctx context.Context, req tfdatasource.ReadRequest, resp *tfdatasource.ReadResponse) {
+	var data WorkflowDefDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	var manifestMap conductorapi.Manifest
+	var err error
+	if data.Version.IsNull() || data.Version.IsUnknown() {
+		manifestMap, err = d.client.GetWorkflowDefManifest(ctx, name)
+	} else {
+		manifestMap, err = d.client.GetWorkflowDefVersionManifest(ctx, name, data.Version.ValueInt32())
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow def %s, got error: %s", name, err))
+		return
+	}
+
+	var workflowDef conductorapi.WorkflowDef
+	err = conductorapi.DecodeManifest(manifestMap, &workflowDef)
+	if err != nil {
+		resp.Diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Unexpected workflow def manifest: %s", err))
+		return
+	}
+
+	taskReferenceNames, taskDefNames := workflowDefTaskNames(workflowDef.Tasks)
+
+	workflowDefCleanup(ctx, manifestMap)
+
+	manifestBytes, err := json.Marshal(manifestMap)
+	if err != nil {
+		resp.Diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Manifest must be a valid json: %s", err))
+		return
+	}
+
+	taskReferenceNamesList, diags := tftypes.ListValueFrom(ctx, tftypes.StringType, taskReferenceNames)
+	resp.Diagnostics.Append(diags...)
+	taskDefNamesList, diags := tftypes.ListValueFrom(ctx, tftypes.StringType, taskDefNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Version = tftypes.Int32Value(workflowDef.Version)
+	data.Manifest = jsontypes.NewNormalizedValue(string(manifestBytes))
+	data.TaskReferenceNames = taskReferenceNamesList
+	data.TaskDefNames = taskDefNamesList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// workflowDefTaskNames returns the reference names of all the tasks and the sorted task def names of the SIMPLE tasks,
+// the tasks of inline sub workflow definitions included.
+func workflowDefTaskNames(tasks []conductorapi.WorkflowTask) ([]string, []string) {
+	taskReferenceNames := []string{}
+	taskDefNames := []string{}
+	conductorapi.WalkWorkflowTasks(tasks, func(task conductorapi.WorkflowTask) {
+		taskReferenceNames = append(taskReferenceNames, task.TaskReferenceName)
+
+		if task.IsSimple() && !slices.Contains(taskDefNames, task.Name) {
+			taskDefNames = append(taskDefNames, task.Name)
+		}
+	})
+	slices.Sort(taskDefNames)
+
+	return taskReferenceNames, taskDefNames
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorapi"
+)
+
+func TestWorkflowDefTaskNames(t *testing.T) {
+	tasks := unmarshalTestJSON[[]conductorapi.WorkflowTask](t, `[
+		{"name":"task_b","taskReferenceName":"task_b_ref"},
+		{"name":"switch","taskReferenceName":"switch_ref","type":"SWITCH","decisionCases":{
+			"case1":[{"name":"task_a","taskReferenceName":"task_a_ref","type":"SIMPLE"}]}},
+		{"name":"sub","taskReferenceName":"sub_ref","type":"SUB_WORKFLOW","subWorkflowParam":{"name":"inline","workflowDefinition":{
+			"name":"inline","tasks":[
+				{"name":"task_c","taskReferenceName":"inline_task_c_ref"},
+				{"name":"http","taskReferenceName":"inline_http_ref","type":"HTTP"}]}}},
+		{"name":"sub_by_name","taskReferenceName":"sub_by_name_ref","type":"SUB_WORKFLOW","subWorkflowParam":{"name":"other"}},
+		{"name":"sub_expression","taskReferenceName":"sub_expression_ref","type":"SUB_WORKFLOW","subWorkflowParam":{
+			"name":"expression","workflowDefinition":"${workflow.input.definition}"}}
+	]`)
+
+	taskReferenceNames, taskDefNames := workflowDefTaskNames(tasks)
+
+	expectedReferenceNames := []string{"task_b_ref", "switch_ref", "task_a_ref", "sub_ref", "inline_task_c_ref", "inline_http_ref",
+		"sub_by_name_ref", "sub_expression_ref"}
+	if !slices.Equal(taskReferenceNames, expectedReferenceNames) {
+		t.Errorf("task reference names = %v, expected %v", taskReferenceNames, expectedReferenceNames)
+	}
+
+	expectedTaskDefNames := []string{"task_a", "task_b", "task_c"}
+	if !slices.Equal(taskDefNames, expectedTaskDefNames) {
+		t.Errorf("task def names = %v, expected %v", taskDefNames, expectedTaskDefNames)
+	}
+}
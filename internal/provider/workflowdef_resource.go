@@ -1,16 +1,17 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
 
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorapi"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
@@ -26,12 +27,31 @@ var _ tfresource.ResourceWithImportState = &WorkflowDefResource{}
 var _ tfresource.ResourceWithModifyPlan = &WorkflowDefResource{}
 
 type WorkflowDefResource struct {
-	client *conductorHttpClient
+	client *conductorapi.Client
+}
+
+const (
+	destroyModeAllVersions        = "all_versions"
+	destroyModeManagedVersionOnly = "managed_version_only"
+	destroyModeRetain             = "retain"
+)
+
+// workflowDefImportPrivateKey marks an imported resource in the private state until the first Read.
+const workflowDefImportPrivateKey = "import"
+
+type workflowDefImport struct {
+	Version int32 `json:"version,omitempty"`
 }
 
 type WorkflowDefModel struct {
-	Manifest jsontypes.Normalized `tfsdk:"manifest"`
-	Version  tftypes.Int32        `tfsdk:"version"`
+	Manifest          ConductorManifest    `tfsdk:"manifest"`
+	EffectiveManifest jsontypes.Normalized `tfsdk:"effective_manifest"`
+	Version           tftypes.Int32        `tfsdk:"version"`
+	RetainVersions    tftypes.Int32        `tfsdk:"retain_versions"`
+	DestroyMode       tftypes.String       `tfsdk:"destroy_mode"`
+	ForceDestroy      tftypes.Bool         `tfsdk:"force_destroy"`
+	Timeouts          timeouts.Value       `tfsdk:"timeouts"`
+	auditModel
 }
 
 var defaultWorkflowDefValues = map[string]interface{}{
@@ -60,18 +80,19 @@ func (r *WorkflowDefResource) Schema(ctx context.Context, req tfresource.SchemaR
 Conductor Workflow Definition
 ## Versioning
 Workflow definition has a "version" field for supporting of keep old version / execution specific version.
-On delete all the workflow definition versions will be deleted.
+On delete all the workflow definition versions will be deleted, unless "destroy_mode" is set.
 The provider support two types of versions modes.
 ### Auto Version Mode
 If you remove the "version" field from the manifest, then on creation the version will be equal to 1. Every update will increment the version by 1.
 ### Manual Version Mode
 If the manifest has a "version" field, it will be used as part of creation and updating. updates will fail if the version will be decreased.
+Refresh reads the managed version, newer versions created outside of Terraform are not reported as drift.
 		`,
 		Attributes: map[string]tfschema.Attribute{
 			"manifest": tfschema.StringAttribute{
 				Description: "The JSON Manifest for the workflow definition",
 				Required:    true,
-				CustomType:  jsontypes.NormalizedType{},
+				CustomType:  workflowDefManifestType,
 				PlanModifiers: []planmodifier.String{
 					nameChangedModifier{},
 				},
@@ -79,9 +100,38 @@ If the manifest has a "version" field, it will be used as part of creation and u
 					manifestNameValidator{},
 				},
 			},
+			"effective_manifest": effectiveManifestAttribute(),
+			"create_time":        auditTimeAttribute("Creation time in epoch milliseconds"),
+			"update_time":        auditTimeAttribute("Last update time in epoch milliseconds"),
+			"created_by":         auditUserAttribute("The user that created the workflow definition"),
+			"updated_by":         auditUserAttribute("The user that last updated the workflow definition"),
 			"version": tfschema.Int32Attribute{
 				Computed: true,
 			},
+			"destroy_mode": tfschema.StringAttribute{
+				MarkdownDescription: "What is deleted on destroy. `all_versions` deletes every version of the workflow definition, " +
+					"`managed_version_only` deletes only the version managed by this resource and `retain` only removes the resource from the state. " +
+					"Defaults to `all_versions`",
+				Optional: true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{destroyModeAllVersions, destroyModeManagedVersionOnly, destroyModeRetain}},
+				},
+			},
+			"force_destroy": tfschema.BoolAttribute{
+				MarkdownDescription: "Delete workflow definition versions even when they have running executions. The value must be applied before the destroy. Defaults to false",
+				Optional:            true,
+			},
+			"retain_versions": tfschema.Int32Attribute{
+				MarkdownDescription: "Number of newest versions to keep, after a successful update older versions are deleted. " +
+					"Versions with running executions and the managed version are never deleted. Not set means all versions are kept",
+				Optional: true,
+				Validators: []validator.Int32{
+					int32AtLeastValidator{min: 1},
+				},
+			},
+		},
+		Blocks: map[string]tfschema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
@@ -113,7 +163,12 @@ func (r *WorkflowDefResource) ModifyPlan(ctx context.Context, req tfresource.Mod
 		return
 	}
 
-	if plan.Manifest.IsNull() || plan.Manifest.IsUnknown() {
+	if plan.Manifest.IsNull() {
+		return
+	}
+
+	if plan.Manifest.IsUnknown() {
+		resp.Diagnostics.Append(setAuditUnknown(ctx, &resp.Plan)...)
 		return
 	}
 
@@ -127,24 +182,20 @@ func (r *WorkflowDefResource) ModifyPlan(ctx context.Context, req tfresource.Mod
 		return
 	}
 
-	var planDef map[string]interface{}
-	err := json.Unmarshal([]byte(plan.Manifest.ValueString()), &planDef)
-	if err != nil {
+	equal, diags := plan.Manifest.StringSemanticEquals(ctx, state.Manifest)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var stateDef map[string]interface{}
-	err = json.Unmarshal([]byte(state.Manifest.ValueString()), &stateDef)
-	if err != nil {
+	if equal {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("manifest"), state.Manifest)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_manifest"), state.EffectiveManifest)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version"), state.Version)...)
 		return
 	}
 
-	workflowDefCleanup(ctx, planDef)
-	workflowDefCleanup(ctx, stateDef)
-
-	if reflect.DeepEqual(planDef, stateDef) {
-		resp.Diagnostics.Append(resp.Plan.Set(ctx, &state)...)
-	}
+	resp.Diagnostics.Append(setAuditUnknown(ctx, &resp.Plan)...)
 }
 
 func (r *WorkflowDefResource) Create(ctx context.Context, req tfresource.CreateRequest, resp *tfresource.CreateResponse) {
@@ -155,6 +206,15 @@ func (r *WorkflowDefResource) Create(ctx context.Context, req tfresource.CreateR
 		return
 	}
 
+	createTimeout, diags := state.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var manifestMap map[string]interface{}
 
 	resp.Diagnostics.Append(state.Manifest.Unmarshal(&manifestMap)...)
@@ -174,43 +234,24 @@ func (r *WorkflowDefResource) Create(ctx context.Context, req tfresource.CreateR
 		}
 		manifestMap["version"] = createVersion
 
-		var requestBody [1]map[string]interface{}
-		requestBody[0] = manifestMap
-
-		requestBytes, err := json.Marshal(requestBody)
+		err := r.client.UpsertWorkflowDef(ctx, manifestMap)
 		if err != nil {
-			resp.Diagnostics.AddError("Invalid Manifest", fmt.Sprintf("Manifest Marshal error: %s", err))
-			return
-		}
-
-		response, err := r.client.do(ctx, http.MethodPut, "metadata/workflow", bytes.NewBuffer(requestBytes))
-
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error sending request: %s", err))
-			return
-		}
-		defer response.Body.Close()
-
-		body, bodyErr := io.ReadAll(response.Body)
-
-		if response.StatusCode != http.StatusOK {
-			if bodyErr != nil {
-				resp.Diagnostics.AddError("HTTP Error", fmt.Sprintf("Received non-OK HTTP status: %s. Failed to read response body: %s",
-					response.Status, bodyErr))
-				return
-			}
-
-			resp.Diagnostics.AddError("HTTP Error", fmt.Sprintf("Received non-OK HTTP status: %s. Body: %s", response.Status, string(body)))
-			return
-		}
-
-		if bodyErr != nil {
-			resp.Diagnostics.AddError("Status was OK but failed to Read Response Body", fmt.Sprintf("Could not read response body: %s", err))
+			addManifestErrorDiagnostics(&resp.Diagnostics, "Unable to create workflow def", err)
 			return
 		}
 	}
 
 	state.Version = tftypes.Int32Value(createVersion)
+	currentManifestMap := r.readCurrentManifest(ctx, manifestMap, createVersion, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.EffectiveManifest = effectiveManifestValue(currentManifestMap, &resp.Diagnostics)
+	state.auditModel = newAuditModel(currentManifestMap, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -222,6 +263,15 @@ func (r *WorkflowDefResource) Read(ctx context.Context, req tfresource.ReadReque
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	var stateManifestMap map[string]interface{}
 
 	resp.Diagnostics.Append(state.Manifest.Unmarshal(&stateManifestMap)...)
@@ -229,47 +279,43 @@ func (r *WorkflowDefResource) Read(ctx context.Context, req tfresource.ReadReque
 		return
 	}
 
-	name := getWorkflowNameFromManifest(stateManifestMap, &resp.Diagnostics)
+	name := getManifestStringValue(stateManifestMap, "name", &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, stateVersionExists, err := getWorkflowVersionOptionalFromManifest(stateManifestMap)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to get version from manifest plan", fmt.Sprintf("Get Version error: %s", err))
+	importBytes, diags := req.Private.GetKey(ctx, workflowDefImportPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	response, err := r.client.do(ctx, http.MethodGet, fmt.Sprintf("metadata/workflow/%s", name), nil)
-
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow, got error: %s", err))
+	if len(importBytes) > 0 {
+		r.readImported(ctx, name, importBytes, &state, resp)
 		return
 	}
 
-	defer response.Body.Close()
-
-	if response.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
+	stateVersion, stateVersionExists, err := getWorkflowVersionOptionalFromManifest(stateManifestMap)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get version from manifest plan", fmt.Sprintf("Get Version error: %s", err))
 		return
 	}
 
-	if response.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("HTTP Get Error", fmt.Sprintf("Received bad HTTP status: %s", response.Status))
-		return
+	//manual version mode reads the managed version, auto version mode the latest
+	var currentManifestMap conductorapi.Manifest
+	if stateVersionExists {
+		currentManifestMap, err = r.client.GetWorkflowDefVersionManifest(ctx, name, stateVersion)
+	} else {
+		currentManifestMap, err = r.client.GetWorkflowDefManifest(ctx, name)
 	}
 
-	bodyBytes, err := io.ReadAll(response.Body)
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading response body", err.Error())
+	if conductorapi.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	var currentManifestMap map[string]interface{}
-
-	err = json.Unmarshal(bodyBytes, &currentManifestMap)
 	if err != nil {
-		resp.Diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Manifest must be a valid json: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow, got error: %s", err))
 		return
 	}
 
@@ -280,6 +326,12 @@ func (r *WorkflowDefResource) Read(ctx context.Context, req tfresource.ReadReque
 		return
 	}
 
+	state.EffectiveManifest = effectiveManifestValue(currentManifestMap, &resp.Diagnostics)
+	state.auditModel = newAuditModel(currentManifestMap, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	for _, f := range auditableFieldsToIgnore {
 		delete(currentManifestMap, f)
 
@@ -301,7 +353,7 @@ func (r *WorkflowDefResource) Read(ctx context.Context, req tfresource.ReadReque
 	}
 
 	state.Version = tftypes.Int32Value(version)
-	state.Manifest = jsontypes.NewNormalizedValue(string(updatedStateBytes))
+	state.Manifest = NewConductorManifestValue(workflowDefManifestType, string(updatedStateBytes))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -312,6 +364,15 @@ func (r *WorkflowDefResource) Delete(ctx context.Context, req tfresource.DeleteR
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	var manifestMap map[string]interface{}
 
 	resp.Diagnostics.Append(state.Manifest.Unmarshal(&manifestMap)...)
@@ -319,51 +380,58 @@ func (r *WorkflowDefResource) Delete(ctx context.Context, req tfresource.DeleteR
 		return
 	}
 
-	name := getWorkflowNameFromManifest(manifestMap, &resp.Diagnostics)
+	name := getManifestStringValue(manifestMap, "name", &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var currentVersion int32
+	var versions []int32
 
-	for {
-		nextVersion, versionExists := getLatestVersion(ctx, r.client, name, &resp.Diagnostics)
-		if resp.Diagnostics.HasError() {
+	switch state.DestroyMode.ValueString() {
+	case destroyModeRetain:
+		tflog.Info(ctx, fmt.Sprintf("destroy_mode is %s, workflow def %s is only removed from the state", destroyModeRetain, name))
+	case destroyModeManagedVersionOnly:
+		versions = append(versions, state.Version.ValueInt32())
+	default:
+		currentVersions, err := r.client.GetWorkflowDefVersions(ctx, name)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list workflow def %s versions, got error: %s", name, err))
 			return
 		}
 
-		if !versionExists {
-			break
-		}
-
-		if currentVersion > 0 && currentVersion == nextVersion {
-			resp.Diagnostics.AddError("Delete failed, try to delete the same version twice", "")
-			return
+		//latest version first
+		for i := len(currentVersions) - 1; i >= 0; i-- {
+			versions = append(versions, currentVersions[i].Version)
 		}
-		currentVersion = nextVersion
+	}
 
-		path := fmt.Sprintf("metadata/workflow/%s/%d", name, currentVersion)
+	if !state.ForceDestroy.ValueBool() {
+		var runningExecutions []string
+		for _, version := range versions {
+			runningIDs, err := r.client.GetRunningWorkflowIDs(ctx, name, version)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get running executions of workflow def %s version %d, got error: %s",
+					name, version, err))
+				return
+			}
 
-		response, err := r.client.do(ctx, http.MethodDelete, path, nil)
+			for _, runningID := range runningIDs {
+				runningExecutions = append(runningExecutions, fmt.Sprintf("version %d: %s", version, runningID))
+			}
+		}
 
-		if err != nil {
-			resp.Diagnostics.AddError("Delete Error", fmt.Sprintf("Unable to delete task def, got error: %s", err))
+		if len(runningExecutions) > 0 {
+			resp.Diagnostics.AddError("Workflow def has running executions",
+				fmt.Sprintf("Workflow def %s can't be deleted while executions are running, set force_destroy to delete anyway. Running executions:\n%s",
+					name, strings.Join(runningExecutions, "\n")))
 			return
 		}
+	}
 
-		defer response.Body.Close()
-
-		if response.StatusCode != http.StatusOK {
-
-			bodyBytes, err := io.ReadAll(response.Body)
-			var bodyStr string
-			if err == nil {
-				bodyStr = string(bodyBytes)
-			} else {
-				bodyStr = fmt.Sprintf("Read All Body Error: %s", err)
-			}
-
-			resp.Diagnostics.AddError("HTTP Error", fmt.Sprintf("Received non-OK HTTP status: %s. Body: %s", response.Status, bodyStr))
+	for _, version := range versions {
+		err := r.client.DeleteWorkflowDefVersion(ctx, name, version)
+		if err != nil && !conductorapi.IsNotFound(err) {
+			resp.Diagnostics.AddError("Delete Error", fmt.Sprintf("Unable to delete workflow def version %d, got error: %s", version, err))
 			return
 		}
 	}
@@ -378,6 +446,44 @@ func (r *WorkflowDefResource) Update(ctx context.Context, req tfresource.UpdateR
 		return
 	}
 
+	updateTimeout, diags := state.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var priorState WorkflowDefModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	//only non manifest attributes (e.g. timeouts) changed, keep the current version
+	manifestEqual, diags := state.Manifest.StringSemanticEquals(ctx, priorState.Manifest)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if manifestEqual {
+		state.Version = priorState.Version
+		state.EffectiveManifest = priorState.EffectiveManifest
+		state.auditModel = priorState.auditModel
+
+		var manifestMap map[string]interface{}
+		resp.Diagnostics.Append(state.Manifest.Unmarshal(&manifestMap)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r.deleteOldVersions(ctx, manifestMap, state.RetainVersions, state.Version.ValueInt32(), &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
 	var manifestMap map[string]interface{}
 	resp.Diagnostics.Append(state.Manifest.Unmarshal(&manifestMap)...)
 	if resp.Diagnostics.HasError() {
@@ -412,47 +518,91 @@ func (r *WorkflowDefResource) Update(ctx context.Context, req tfresource.UpdateR
 		manifestMap["version"] = newVersion
 	}
 
-	var requestBody [1]map[string]interface{}
-	requestBody[0] = manifestMap
+	err = r.client.UpsertWorkflowDef(ctx, manifestMap)
+	if conductorapi.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
-	requestBytes, err := json.Marshal(requestBody)
 	if err != nil {
-		resp.Diagnostics.AddError("Invalid Manifest", fmt.Sprintf("Manifest Marshal error: %s", err))
+		addManifestErrorDiagnostics(&resp.Diagnostics, "Unable to update workflow def", err)
 		return
 	}
 
-	response, err := r.client.do(ctx, http.MethodPut, "metadata/workflow", bytes.NewBuffer(requestBytes))
+	state.Version = tftypes.Int32Value(newVersion)
+	currentManifestMap := r.readCurrentManifest(ctx, manifestMap, newVersion, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error sending request: %s", err))
+	state.EffectiveManifest = effectiveManifestValue(currentManifestMap, &resp.Diagnostics)
+	state.auditModel = newAuditModel(currentManifestMap, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	defer response.Body.Close()
 
-	if response.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
+	r.deleteOldVersions(ctx, manifestMap, state.RetainVersions, newVersion, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// deleteOldVersions deletes the versions older than the newest 'retain_versions' versions.
+// Versions with running executions and the managed version are skipped, failures are reported as warnings
+// since the update itself already succeeded.
+func (r *WorkflowDefResource) deleteOldVersions(ctx context.Context, manifestMap map[string]interface{}, retainVersions tftypes.Int32,
+	managedVersion int32, diagnostics *diag.Diagnostics) {
+
+	if retainVersions.IsNull() || retainVersions.IsUnknown() {
 		return
 	}
 
-	if response.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(response.Body)
-		if err != nil {
-			resp.Diagnostics.AddError("Failed to Read Response Body", fmt.Sprintf("Received non-OK HTTP status: %s, Could not read response body: %s", response.Status, err))
-			return
-		}
-		resp.Diagnostics.AddError("HTTP Error", fmt.Sprintf("Received non-OK HTTP status: %s, Body: %s", response.Status, string(body)))
+	name := getManifestStringValue(manifestMap, "name", diagnostics)
+	if diagnostics.HasError() {
 		return
 	}
 
-	state.Version = tftypes.Int32Value(newVersion)
+	versions, err := r.client.GetWorkflowDefVersions(ctx, name)
+	if err != nil {
+		diagnostics.AddWarning("Retain Versions Error", fmt.Sprintf("Unable to list workflow def %s versions, got error: %s", name, err))
+		return
+	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	deleteCount := len(versions) - int(retainVersions.ValueInt32())
+	for i := 0; i < deleteCount; i++ {
+		version := versions[i].Version
+		if version == managedVersion {
+			continue
+		}
+
+		runningIDs, err := r.client.GetRunningWorkflowIDs(ctx, name, version)
+		if err != nil {
+			diagnostics.AddWarning("Retain Versions Error", fmt.Sprintf("Unable to get running executions of workflow def %s version %d, got error: %s",
+				name, version, err))
+			continue
+		}
+
+		if len(runningIDs) > 0 {
+			tflog.Info(ctx, fmt.Sprintf("Keeping workflow def %s version %d, it has %d running executions", name, version, len(runningIDs)))
+			continue
+		}
+
+		err = r.client.DeleteWorkflowDefVersion(ctx, name, version)
+		if err != nil && !conductorapi.IsNotFound(err) {
+			diagnostics.AddWarning("Retain Versions Error", fmt.Sprintf("Unable to delete workflow def %s version %d, got error: %s", name, version, err))
+		}
+	}
 }
 
+// ImportState supports the import IDs "name" (latest version, auto version mode) and "name:version" (manual version mode).
+// The next Read hydrates the state with the complete cleaned manifest of the imported version.
 func (r *WorkflowDefResource) ImportState(ctx context.Context, req tfresource.ImportStateRequest, resp *tfresource.ImportStateResponse) {
+	name, imported, err := parseWorkflowDefImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Expected import ID 'name' or 'name:version', got: %s. %s", req.ID, err))
+		return
+	}
 
 	initialStateMap := map[string]interface{}{
-		"name": req.ID,
+		"name": name,
 	}
 
 	manifestBytes, err := json.Marshal(initialStateMap)
@@ -461,23 +611,110 @@ func (r *WorkflowDefResource) ImportState(ctx context.Context, req tfresource.Im
 		return
 	}
 
+	importBytes, err := json.Marshal(imported)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Import Marshal error: %s", err))
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("manifest"), string(manifestBytes))...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, workflowDefImportPrivateKey, importBytes)...)
 }
 
-func getWorkflowNameFromManifest(manifestMap map[string]interface{}, diagnostics *diag.Diagnostics) string {
-	taskTypeVal, ok := manifestMap["name"]
-	if !ok {
-		diagnostics.AddError("Invalid Manifest", "'name' parameter is missing from manifest")
-		return ""
+// readImported hydrates the state of an imported workflow def with the complete cleaned manifest of the imported version.
+func (r *WorkflowDefResource) readImported(ctx context.Context, name string, importBytes []byte, state *WorkflowDefModel, resp *tfresource.ReadResponse) {
+	var imported workflowDefImport
+	err := json.Unmarshal(importBytes, &imported)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import state", fmt.Sprintf("Import Unmarshal error: %s", err))
+		return
+	}
+
+	var currentManifestMap conductorapi.Manifest
+	if imported.Version > 0 {
+		currentManifestMap, err = r.client.GetWorkflowDefVersionManifest(ctx, name, imported.Version)
+	} else {
+		currentManifestMap, err = r.client.GetWorkflowDefManifest(ctx, name)
+	}
+
+	if conductorapi.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow, got error: %s", err))
+		return
+	}
+
+	version, err := getWorkflowVersionFromManifest(currentManifestMap)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Error. failed ot extract version from current manifest", err.Error())
+		return
 	}
 
-	taskType, ok := taskTypeVal.(string)
-	if !ok || taskType == "" {
-		diagnostics.AddError("Invalid Manifest", "'name' parameter must be string")
-		return ""
+	state.EffectiveManifest = effectiveManifestValue(currentManifestMap, &resp.Diagnostics)
+	state.auditModel = newAuditModel(currentManifestMap, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, f := range auditableFieldsToIgnore {
+		delete(currentManifestMap, f)
 	}
 
-	return taskType
+	//auto version mode
+	if imported.Version == 0 {
+		delete(currentManifestMap, "version")
+	}
+
+	workflowDefCleanup(ctx, currentManifestMap)
+
+	manifestBytes, err := json.Marshal(currentManifestMap)
+	if err != nil {
+		resp.Diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Manifest must be a valid json: %s", err))
+		return
+	}
+
+	state.Version = tftypes.Int32Value(version)
+	state.Manifest = NewConductorManifestValue(workflowDefManifestType, string(manifestBytes))
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, workflowDefImportPrivateKey, nil)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func parseWorkflowDefImportID(id string) (string, workflowDefImport, error) {
+	name, versionStr, hasVersion := strings.Cut(id, ":")
+	if name == "" {
+		return "", workflowDefImport{}, fmt.Errorf("name is empty")
+	}
+
+	if !hasVersion {
+		return name, workflowDefImport{}, nil
+	}
+
+	version, err := strconv.ParseInt(versionStr, 10, 32)
+	if err != nil || version < 1 {
+		return "", workflowDefImport{}, fmt.Errorf("version must be a positive int")
+	}
+
+	return name, workflowDefImport{Version: int32(version)}, nil
+}
+
+// readCurrentManifest reads the workflow def version as stored by the server after create / update.
+func (r *WorkflowDefResource) readCurrentManifest(ctx context.Context, manifestMap map[string]interface{}, version int32, diagnostics *diag.Diagnostics) map[string]interface{} {
+	name := getManifestStringValue(manifestMap, "name", diagnostics)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	currentManifestMap, err := r.client.GetWorkflowDefVersionManifest(ctx, name, version)
+	if err != nil {
+		diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow def %s version %d, got error: %s", name, version, err))
+		return nil
+	}
+
+	return currentManifestMap
 }
 
 func getWorkflowVersionOptionalFromManifest(manifestMap map[string]interface{}) (int32, bool, error) {
@@ -544,6 +781,11 @@ func workflowDefCleanup(ctx context.Context, currentManifestMap map[string]inter
 		return
 	}
 
+	workflowTasksCleanup(ctx, currentTasksArr)
+}
+
+// workflowTasksCleanup cleanups the tasks and all their nested tasks and inline sub workflow definitions.
+func workflowTasksCleanup(ctx context.Context, currentTasksArr []interface{}) {
 	for i := 0; i < len(currentTasksArr); i++ {
 
 		currentTask, ok := currentTasksArr[i].(map[string]interface{})
@@ -552,104 +794,232 @@ func workflowDefCleanup(ctx context.Context, currentManifestMap map[string]inter
 			continue
 		}
 
+		for _, nestedTasks := range getNestedWorkflowTasks(ctx, currentTask) {
+			workflowTasksCleanup(ctx, nestedTasks.tasks)
+		}
+
+		if inlineDef := getInlineSubWorkflowDef(currentTask); inlineDef != nil {
+			workflowDefCleanup(ctx, inlineDef)
+		}
+
 		cleanupManifestDefaults(ctx, currentTask, defaultWorkflowDefTaskValues)
 	}
 }
 
 func workflowDefMerge(ctx context.Context, currentManifestMap map[string]interface{}, stateManifestMap map[string]interface{}) {
-	mergeManifestMaps(ctx, currentManifestMap, stateManifestMap)
+	//the generic merge takes the tasks from the server, keep the state tasks to merge them by reference name
+	stateTasksVal, stateTasksExist := stateManifestMap["tasks"]
 
-	currentTasksVal, ok := currentManifestMap["tasks"]
-	if !ok {
-		tflog.Error(ctx, "current map 'tasks' key not found")
+	mergeManifestMaps(ctx, currentManifestMap, stateManifestMap, defaultWorkflowDefValues)
+
+	if !stateTasksExist {
+		tflog.Error(ctx, "state map 'tasks' key not found")
 		return
 	}
 
-	currentTasksArr, ok := currentTasksVal.([]interface{})
+	stateTasksArr, ok := stateTasksVal.([]interface{})
 	if !ok {
-		tflog.Error(ctx, fmt.Sprintf("current map 'tasks' key is not valid a slice. type: %T", currentTasksVal))
+		tflog.Error(ctx, fmt.Sprintf("state map 'tasks' key is not valid a slice. type: %T", stateTasksVal))
 		return
 	}
 
-	stateTasksVal, ok := stateManifestMap["tasks"]
+	workflowDefTasksMerge(ctx, stateManifestMap, stateTasksArr)
+}
+
+// workflowDefTasksMerge re-merges the tasks of an already merged workflow definition, holding the current tasks,
+// with the tasks the state had before the merge.
+func workflowDefTasksMerge(ctx context.Context, mergedManifestMap map[string]interface{}, stateTasksArr []interface{}) {
+	currentTasksVal, ok := mergedManifestMap["tasks"]
 	if !ok {
-		tflog.Error(ctx, "state map 'tasks' key not found")
+		tflog.Error(ctx, "current map 'tasks' key not found")
 		return
 	}
 
-	stateTasksArr, ok := stateTasksVal.([]interface{})
+	currentTasksArr, ok := currentTasksVal.([]interface{})
 	if !ok {
-		tflog.Error(ctx, fmt.Sprintf("state map 'tasks' key is not valid a slice. type: %T", stateTasksVal))
+		tflog.Error(ctx, fmt.Sprintf("current map 'tasks' key is not valid a slice. type: %T", currentTasksVal))
 		return
 	}
 
-	for i := 0; i < len(currentTasksArr); i++ {
-		if i >= len(stateTasksArr) {
-			break
-		}
+	mergedManifestMap["tasks"] = workflowTasksMerge(ctx, currentTasksArr, stateTasksArr)
+}
 
-		currentTask, ok := currentTasksArr[i].(map[string]interface{})
+// workflowTasksMerge merges the current tasks into the state tasks matched by 'taskReferenceName' and returns the merged tasks list.
+// The result follows the current tasks order, tasks added remotely are added as is and tasks removed remotely are dropped.
+// Nested tasks and inline sub workflow definitions are merged the same way.
+func workflowTasksMerge(ctx context.Context, currentTasksArr []interface{}, stateTasksArr []interface{}) []interface{} {
+	stateTasksByRef := make(map[string]map[string]interface{})
+	for i, stateTaskVal := range stateTasksArr {
+		stateTask, ok := stateTaskVal.(map[string]interface{})
 		if !ok {
-			tflog.Error(ctx, fmt.Sprintf("current map 'task' index: %d, is not valid a map. type: %T", i, currentTasksArr[i]))
+			tflog.Error(ctx, fmt.Sprintf("state map 'task' index: %d, is not valid a map. type: %T", i, stateTaskVal))
 			continue
 		}
 
-		stateTask, ok := stateTasksArr[i].(map[string]interface{})
+		if taskRef := getTaskReferenceName(stateTask); taskRef != "" {
+			stateTasksByRef[taskRef] = stateTask
+		}
+	}
+
+	mergedTasksArr := make([]interface{}, 0, len(currentTasksArr))
+
+	for i, currentTaskVal := range currentTasksArr {
+		currentTask, ok := currentTaskVal.(map[string]interface{})
 		if !ok {
-			tflog.Error(ctx, fmt.Sprintf("state map 'task' index: %d, is not valid a map. type: %T", i, stateTasksArr[i]))
+			tflog.Error(ctx, fmt.Sprintf("current map 'task' index: %d, is not valid a map. type: %T", i, currentTaskVal))
+			mergedTasksArr = append(mergedTasksArr, currentTaskVal)
 			continue
 		}
 
-		mergeManifestMaps(ctx, currentTask, stateTask)
+		taskRef := getTaskReferenceName(currentTask)
+		stateTask, ok := stateTasksByRef[taskRef]
+		if taskRef == "" || !ok {
+			tflog.Debug(ctx, fmt.Sprintf("task '%s' added remotely", taskRef))
+			mergedTasksArr = append(mergedTasksArr, currentTask)
+			continue
+		}
+		delete(stateTasksByRef, taskRef)
+
+		//the generic merge takes nested task lists from the server, keep the state lists to re-merge them by reference name
+		stateNestedTasks := getNestedWorkflowTasks(ctx, stateTask)
+		var stateInlineTasksArr []interface{}
+		if stateInlineDef := getInlineSubWorkflowDef(stateTask); stateInlineDef != nil {
+			stateInlineTasksArr, _ = stateInlineDef["tasks"].([]interface{})
+		}
+
+		mergeManifestMaps(ctx, currentTask, stateTask, defaultWorkflowDefTaskValues)
+
+		for location, mergedNested := range getNestedWorkflowTasks(ctx, stateTask) {
+			if stateNested, ok := stateNestedTasks[location]; ok {
+				mergedNested.set(workflowTasksMerge(ctx, mergedNested.tasks, stateNested.tasks))
+			}
+		}
+
+		if mergedInlineDef := getInlineSubWorkflowDef(stateTask); mergedInlineDef != nil && stateInlineTasksArr != nil {
+			workflowDefTasksMerge(ctx, mergedInlineDef, stateInlineTasksArr)
+		}
+
+		mergedTasksArr = append(mergedTasksArr, stateTask)
+	}
+
+	for taskRef := range stateTasksByRef {
+		tflog.Debug(ctx, fmt.Sprintf("task '%s' removed remotely", taskRef))
 	}
+
+	return mergedTasksArr
 }
 
-func checkExistingVersionBeforeCreate(ctx context.Context, client *conductorHttpClient, planMap map[string]interface{}, diagnostics *diag.Diagnostics) (int32, bool) {
-	name := getWorkflowNameFromManifest(planMap, diagnostics)
-	if diagnostics.HasError() {
-		return 0, false
+func getTaskReferenceName(task map[string]interface{}) string {
+	taskRef, _ := task["taskReferenceName"].(string)
+	return taskRef
+}
+
+type nestedWorkflowTasks struct {
+	tasks []interface{}
+	set   func(tasks []interface{})
+}
+
+// getNestedWorkflowTasks returns the nested task lists of SWITCH / DECISION / FORK_JOIN / DO_WHILE tasks by their location in the task.
+// e.g. - "decisionCases.case1", "defaultCase", "forkTasks[0]", "loopOver".
+func getNestedWorkflowTasks(ctx context.Context, task map[string]interface{}) map[string]nestedWorkflowTasks {
+	nestedTasks := make(map[string]nestedWorkflowTasks)
+
+	if decisionCasesVal, ok := task["decisionCases"]; ok {
+		decisionCases, ok := decisionCasesVal.(map[string]interface{})
+		if !ok {
+			tflog.Error(ctx, fmt.Sprintf("task 'decisionCases' key is not valid a map. type: %T", decisionCasesVal))
+		}
+
+		for caseName, caseTasksVal := range decisionCases {
+			caseTasksArr, ok := caseTasksVal.([]interface{})
+			if !ok {
+				tflog.Error(ctx, fmt.Sprintf("task 'decisionCases.%s' key is not valid a slice. type: %T", caseName, caseTasksVal))
+				continue
+			}
+
+			nestedTasks[fmt.Sprintf("decisionCases.%s", caseName)] = nestedWorkflowTasks{
+				tasks: caseTasksArr,
+				set:   func(tasks []interface{}) { decisionCases[caseName] = tasks },
+			}
+		}
 	}
 
-	version, versionExists, err := getWorkflowVersionOptionalFromManifest(planMap)
-	tflog.Debug(ctx, fmt.Sprintf("Version Found: %t, Version: %d", versionExists, version))
-	if err != nil {
-		diagnostics.AddError(fmt.Sprintf("Manifest get version err: %s", err), "")
-		return 0, false
+	for _, key := range []string{"defaultCase", "loopOver"} {
+		if tasksVal, ok := task[key]; ok {
+			tasksArr, ok := tasksVal.([]interface{})
+			if !ok {
+				tflog.Error(ctx, fmt.Sprintf("task '%s' key is not valid a slice. type: %T", key, tasksVal))
+				continue
+			}
+
+			nestedTasks[key] = nestedWorkflowTasks{
+				tasks: tasksArr,
+				set:   func(tasks []interface{}) { task[key] = tasks },
+			}
+		}
 	}
 
-	latestPath := fmt.Sprintf("metadata/workflow/%s", name)
+	if forkTasksVal, ok := task["forkTasks"]; ok {
+		forkTasks, ok := forkTasksVal.([]interface{})
+		if !ok {
+			tflog.Error(ctx, fmt.Sprintf("task 'forkTasks' key is not valid a slice. type: %T", forkTasksVal))
+		}
 
-	response, err := client.do(ctx, http.MethodGet, latestPath, nil)
+		for i, forkBranchVal := range forkTasks {
+			forkBranchArr, ok := forkBranchVal.([]interface{})
+			if !ok {
+				tflog.Error(ctx, fmt.Sprintf("task 'forkTasks[%d]' is not valid a slice. type: %T", i, forkBranchVal))
+				continue
+			}
 
-	if err != nil {
-		diagnostics.AddError("Failed to get Manifest", fmt.Sprintf("Manifest get err: %s", err))
-		return 0, false
+			nestedTasks[fmt.Sprintf("forkTasks[%d]", i)] = nestedWorkflowTasks{
+				tasks: forkBranchArr,
+				set:   func(tasks []interface{}) { forkTasks[i] = tasks },
+			}
+		}
 	}
-	defer response.Body.Close()
 
-	if response.StatusCode == http.StatusNotFound {
-		if versionExists {
-			return version, true
-		}
-		return 1, true
+	return nestedTasks
+}
+
+// getInlineSubWorkflowDef returns the SUB_WORKFLOW task inline definition (subWorkflowParam.workflowDefinition), nil if not exists.
+func getInlineSubWorkflowDef(task map[string]interface{}) map[string]interface{} {
+	subWorkflowParam, ok := task["subWorkflowParam"].(map[string]interface{})
+	if !ok {
+		return nil
 	}
 
-	bodyBytes, err := io.ReadAll(response.Body)
-	if err != nil {
-		diagnostics.AddError("Error reading response body", fmt.Sprintf("Status Code: %s, Error: %s", response.Status, err))
+	inlineDef, ok := subWorkflowParam["workflowDefinition"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	return inlineDef
+}
+
+func checkExistingVersionBeforeCreate(ctx context.Context, client *conductorapi.Client, planMap map[string]interface{}, diagnostics *diag.Diagnostics) (int32, bool) {
+	name := getManifestStringValue(planMap, "name", diagnostics)
+	if diagnostics.HasError() {
 		return 0, false
 	}
 
-	if response.StatusCode != http.StatusOK {
-		diagnostics.AddError("HTTP Get Error", fmt.Sprintf("Received bad HTTP status: %s. Body: %s", response.Status, string(bodyBytes)))
+	version, versionExists, err := getWorkflowVersionOptionalFromManifest(planMap)
+	tflog.Debug(ctx, fmt.Sprintf("Version Found: %t, Version: %d", versionExists, version))
+	if err != nil {
+		diagnostics.AddError(fmt.Sprintf("Manifest get version err: %s", err), "")
 		return 0, false
 	}
 
-	var currentManifestMap map[string]interface{}
+	currentManifestMap, err := client.GetWorkflowDefManifest(ctx, name)
+	if conductorapi.IsNotFound(err) {
+		if versionExists {
+			return version, true
+		}
+		return 1, true
+	}
 
-	err = json.Unmarshal(bodyBytes, &currentManifestMap)
 	if err != nil {
-		diagnostics.AddError("Current Manifest JSON Parse error", fmt.Sprintf("Manifest must be a valid json: %s", err))
+		diagnostics.AddError("Failed to get Manifest", fmt.Sprintf("Manifest get err: %s", err))
 		return 0, false
 	}
 
@@ -682,46 +1052,14 @@ func checkExistingVersionBeforeCreate(ctx context.Context, client *conductorHttp
 	return currentVersion + 1, true
 }
 
-func verifyValidVersionForUpdate(ctx context.Context, client *conductorHttpClient, planMap map[string]interface{}, planVersion int32, diagnostics *diag.Diagnostics) {
-	name := getWorkflowNameFromManifest(planMap, diagnostics)
+func verifyValidVersionForUpdate(ctx context.Context, client *conductorapi.Client, planMap map[string]interface{}, planVersion int32, diagnostics *diag.Diagnostics) {
+	name := getManifestStringValue(planMap, "name", diagnostics)
 	if diagnostics.HasError() {
 		return
 	}
 
-	latestPath := fmt.Sprintf("metadata/workflow/%s", name)
-
-	response, err := client.do(ctx, http.MethodGet, latestPath, nil)
-	if err != nil {
-		diagnostics.AddError("Failed to get Manifest", fmt.Sprintf("Manifest get err: %s", err))
-	}
-
-	defer response.Body.Close()
-
-	if response.StatusCode == http.StatusNotFound {
-		return
-	}
-
-	bodyBytes, err := io.ReadAll(response.Body)
-	if err != nil {
-		diagnostics.AddError("Error reading response body", fmt.Sprintf("Status Code: %s, Error: %s", response.Status, err))
-	}
-
-	if response.StatusCode != http.StatusOK {
-		diagnostics.AddError("HTTP Get Error", fmt.Sprintf("Received bad HTTP status: %s. Body: %s", response.Status, string(bodyBytes)))
-		return
-	}
-
-	var currentManifestMap map[string]interface{}
-
-	err = json.Unmarshal(bodyBytes, &currentManifestMap)
-	if err != nil {
-		diagnostics.AddError("Current Manifest JSON Parse error", fmt.Sprintf("Manifest must be a valid json: %s", err))
-		return
-	}
-
-	currentVersion, err := getWorkflowVersionFromManifest(currentManifestMap)
-	if err != nil {
-		diagnostics.AddError("Invalid Current Manifest", fmt.Sprintf("Manifest get version err: %s", err))
+	currentVersion, versionExists := getLatestVersion(ctx, client, name, diagnostics)
+	if diagnostics.HasError() || !versionExists {
 		return
 	}
 
@@ -730,44 +1068,16 @@ func verifyValidVersionForUpdate(ctx context.Context, client *conductorHttpClien
 	}
 }
 
-func getLatestVersion(ctx context.Context, client *conductorHttpClient, name string, diagnostics *diag.Diagnostics) (int32, bool) {
-	latestPath := fmt.Sprintf("metadata/workflow/%s", name)
-
-	response, err := client.do(ctx, http.MethodGet, latestPath, nil)
-	if err != nil {
-		diagnostics.AddError("Failed to get Manifest", fmt.Sprintf("Manifest get err: %s", err))
-		return 0, false
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode == http.StatusNotFound {
-		return 0, false
-	}
-
-	bodyBytes, err := io.ReadAll(response.Body)
-	if err != nil {
-		diagnostics.AddError("Error reading response body", fmt.Sprintf("Status Code: %s, Error: %s", response.Status, err))
-		return 0, false
-	}
-
-	if response.StatusCode != http.StatusOK {
-		diagnostics.AddError("HTTP Get Error", fmt.Sprintf("Received bad HTTP status: %s. Body: %s", response.Status, string(bodyBytes)))
+func getLatestVersion(ctx context.Context, client *conductorapi.Client, name string, diagnostics *diag.Diagnostics) (int32, bool) {
+	workflowDef, err := client.GetWorkflowDef(ctx, name)
+	if conductorapi.IsNotFound(err) {
 		return 0, false
 	}
 
-	var currentManifestMap map[string]interface{}
-
-	err = json.Unmarshal(bodyBytes, &currentManifestMap)
 	if err != nil {
-		diagnostics.AddError("Current Manifest JSON Parse error", fmt.Sprintf("Manifest must be a valid json: %s", err))
-		return 0, false
-	}
-
-	currentVersion, err := getWorkflowVersionFromManifest(currentManifestMap)
-	if err != nil {
-		diagnostics.AddError("Invalid Current Manifest", fmt.Sprintf("Manifest get version err: %s", err))
+		diagnostics.AddError("Failed to get Manifest", fmt.Sprintf("Manifest get err: %s", err))
 		return 0, false
 	}
 
-	return currentVersion, true
+	return workflowDef.Version, true
 }
@@ -0,0 +1,179 @@
+//go:build acceptance
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func testAccWorkflowDefConfig(providerConfig string, description string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "conductor_workflowdef" "test" {
+  manifest = jsonencode({
+    name        = "acc_workflow"
+    description = %q
+    tasks = [
+      {
+        name              = "acc_task"
+        taskReferenceName = "acc_task_ref"
+        inputParameters = {
+          value = "$${workflow.input.value}"
+        }
+      }
+    ]
+  })
+}
+`, description)
+}
+
+func TestAccWorkflowDefResource(t *testing.T) {
+	fake, providerConfig := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read
+			{
+				Config: testAccWorkflowDefConfig(providerConfig, "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("conductor_workflowdef.test", "version", "1"),
+					resource.TestMatchResourceAttr("conductor_workflowdef.test", "effective_manifest", regexp.MustCompile(`"timeoutPolicy":"ALERT_ONLY"`)),
+					resource.TestCheckResourceAttrSet("conductor_workflowdef.test", "create_time"),
+				),
+			},
+			// Update and Read, auto version mode creates a new version
+			{
+				Config: testAccWorkflowDefConfig(providerConfig, "second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("conductor_workflowdef.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("conductor_workflowdef.test", "version", "2"),
+					resource.TestMatchResourceAttr("conductor_workflowdef.test", "effective_manifest", regexp.MustCompile(`"description":"second"`)),
+					resource.TestCheckResourceAttrSet("conductor_workflowdef.test", "update_time"),
+				),
+			},
+			// ImportState of the latest version
+			{
+				ResourceName:                         "conductor_workflowdef.test",
+				ImportState:                          true,
+				ImportStateId:                        "acc_workflow",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "manifest",
+			},
+			// Drift: a task changed remotely, the plan restores the configured manifest in a new version
+			{
+				PreConfig: func() {
+					edited := fake.EditWorkflowDef("acc_workflow", 2, func(workflowDef map[string]interface{}) {
+						tasks, ok := workflowDef["tasks"].([]interface{})
+						if !ok || len(tasks) == 0 {
+							t.Fatalf("unexpected tasks in workflow def acc_workflow: %v", workflowDef["tasks"])
+						}
+						task, ok := tasks[0].(map[string]interface{})
+						if !ok {
+							t.Fatalf("unexpected task in workflow def acc_workflow: %v", tasks[0])
+						}
+						task["inputParameters"] = map[string]interface{}{"value": "changed"}
+					})
+					if !edited {
+						t.Fatal("workflow def acc_workflow version 2 not found in the fake server")
+					}
+				},
+				Config: testAccWorkflowDefConfig(providerConfig, "second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("conductor_workflowdef.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("conductor_workflowdef.test", "version", "3"),
+					resource.TestMatchResourceAttr("conductor_workflowdef.test", "effective_manifest",
+						regexp.MustCompile(`"inputParameters":\{"value":"\$\{workflow.input.value\}"\}`)),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccWorkflowDefVersionConfig(providerConfig string) string {
+	return providerConfig + `
+resource "conductor_workflowdef" "test" {
+  manifest = jsonencode({
+    name        = "acc_versioned_workflow"
+    version     = 1
+    description = "version 1"
+    tasks = [
+      {
+        name              = "acc_task"
+        taskReferenceName = "acc_task_ref"
+      }
+    ]
+  })
+}
+`
+}
+
+func TestAccWorkflowDefResourceImportVersion(t *testing.T) {
+	fake, providerConfig := testAccFakeServer(t)
+
+	for version := 1; version <= 2; version++ {
+		added := fake.AddWorkflowDef(map[string]interface{}{
+			"name":        "acc_versioned_workflow",
+			"version":     float64(version),
+			"description": fmt.Sprintf("version %d", version),
+			"tasks": []interface{}{
+				map[string]interface{}{"name": "acc_task", "taskReferenceName": "acc_task_ref"},
+			},
+		})
+		if !added {
+			t.Fatalf("workflow def acc_versioned_workflow version %d not added to the fake server", version)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Import a version older than the latest
+			{
+				Config:             testAccWorkflowDefVersionConfig(providerConfig),
+				ResourceName:       "conductor_workflowdef.test",
+				ImportState:        true,
+				ImportStateId:      "acc_versioned_workflow:1",
+				ImportStatePersist: true,
+			},
+			// Refresh reads the managed version, a newer version created outside of terraform is not drift
+			{
+				PreConfig: func() {
+					added := fake.AddWorkflowDef(map[string]interface{}{
+						"name":        "acc_versioned_workflow",
+						"version":     float64(3),
+						"description": "version 3",
+						"tasks": []interface{}{
+							map[string]interface{}{"name": "acc_task", "taskReferenceName": "acc_task_ref"},
+						},
+					})
+					if !added {
+						t.Fatal("workflow def acc_versioned_workflow version 3 not added to the fake server")
+					}
+				},
+				Config:   testAccWorkflowDefVersionConfig(providerConfig),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccWorkflowDefVersionConfig(providerConfig),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("conductor_workflowdef.test", "version", "1"),
+					resource.TestMatchResourceAttr("conductor_workflowdef.test", "effective_manifest", regexp.MustCompile(`"description":"version 1"`)),
+				),
+			},
+		},
+	})
+}
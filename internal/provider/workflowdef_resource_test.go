@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func unmarshalTestJSON[T any](t *testing.T, value string) T {
+	t.Helper()
+
+	var out T
+	if err := json.Unmarshal([]byte(value), &out); err != nil {
+		t.Fatalf("invalid test json %s: %s", value, err)
+	}
+	return out
+}
+
+func TestWorkflowTasksMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		state    string
+		expected string
+	}{
+		{
+			name:     "unchanged",
+			current:  `[{"name":"a","taskReferenceName":"a_ref"},{"name":"b","taskReferenceName":"b_ref"}]`,
+			state:    `[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE"},{"name":"b","taskReferenceName":"b_ref"}]`,
+			expected: `[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE"},{"name":"b","taskReferenceName":"b_ref"}]`,
+		},
+		{
+			name:     "changed remotely",
+			current:  `[{"name":"a","taskReferenceName":"a_ref","inputParameters":{"value":"changed"}}]`,
+			state:    `[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE","inputParameters":{"value":"configured"}}]`,
+			expected: `[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE","inputParameters":{"value":"changed"}}]`,
+		},
+		{
+			name:     "inserted remotely",
+			current:  `[{"name":"a","taskReferenceName":"a_ref"},{"name":"b","taskReferenceName":"b_ref"},{"name":"c","taskReferenceName":"c_ref"}]`,
+			state:    `[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE"},{"name":"c","taskReferenceName":"c_ref","type":"SIMPLE"}]`,
+			expected: `[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE"},{"name":"b","taskReferenceName":"b_ref"},{"name":"c","taskReferenceName":"c_ref","type":"SIMPLE"}]`,
+		},
+		{
+			name:     "removed remotely",
+			current:  `[{"name":"c","taskReferenceName":"c_ref"}]`,
+			state:    `[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE"},{"name":"b","taskReferenceName":"b_ref"},{"name":"c","taskReferenceName":"c_ref","type":"SIMPLE"}]`,
+			expected: `[{"name":"c","taskReferenceName":"c_ref","type":"SIMPLE"}]`,
+		},
+		{
+			name:     "reordered remotely",
+			current:  `[{"name":"b","taskReferenceName":"b_ref"},{"name":"a","taskReferenceName":"a_ref"}]`,
+			state:    `[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE"},{"name":"b","taskReferenceName":"b_ref","optional":false}]`,
+			expected: `[{"name":"b","taskReferenceName":"b_ref","optional":false},{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE"}]`,
+		},
+		{
+			name: "nested decision cases",
+			current: `[{"name":"switch","taskReferenceName":"switch_ref","type":"SWITCH",
+				"decisionCases":{"case1":[{"name":"t1","taskReferenceName":"t1_ref","inputParameters":{"value":"changed"}},{"name":"t3","taskReferenceName":"t3_ref"}]},
+				"defaultCase":[{"name":"t4","taskReferenceName":"t4_ref"}]}]`,
+			state: `[{"name":"switch","taskReferenceName":"switch_ref","type":"SWITCH",
+				"decisionCases":{"case1":[{"name":"t1","taskReferenceName":"t1_ref","type":"SIMPLE","inputParameters":{"value":"configured"}},{"name":"t2","taskReferenceName":"t2_ref"}]},
+				"defaultCase":[{"name":"t4","taskReferenceName":"t4_ref","type":"SIMPLE"}]}]`,
+			expected: `[{"name":"switch","taskReferenceName":"switch_ref","type":"SWITCH",
+				"decisionCases":{"case1":[{"name":"t1","taskReferenceName":"t1_ref","type":"SIMPLE","inputParameters":{"value":"changed"}},{"name":"t3","taskReferenceName":"t3_ref"}]},
+				"defaultCase":[{"name":"t4","taskReferenceName":"t4_ref","type":"SIMPLE"}]}]`,
+		},
+		{
+			name: "fork tasks",
+			current: `[{"name":"fork","taskReferenceName":"fork_ref","type":"FORK_JOIN",
+				"forkTasks":[[{"name":"a","taskReferenceName":"a_ref","inputParameters":{"value":"changed"}}],[{"name":"b","taskReferenceName":"b_ref"}]]}]`,
+			state: `[{"name":"fork","taskReferenceName":"fork_ref","type":"FORK_JOIN",
+				"forkTasks":[[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE","inputParameters":{"value":"configured"}}],[{"name":"b","taskReferenceName":"b_ref","type":"SIMPLE"}]]}]`,
+			expected: `[{"name":"fork","taskReferenceName":"fork_ref","type":"FORK_JOIN",
+				"forkTasks":[[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE","inputParameters":{"value":"changed"}}],[{"name":"b","taskReferenceName":"b_ref","type":"SIMPLE"}]]}]`,
+		},
+		{
+			name: "loop over",
+			current: `[{"name":"loop","taskReferenceName":"loop_ref","type":"DO_WHILE",
+				"loopOver":[{"name":"a","taskReferenceName":"a_ref"},{"name":"b","taskReferenceName":"b_ref"}]}]`,
+			state: `[{"name":"loop","taskReferenceName":"loop_ref","type":"DO_WHILE",
+				"loopOver":[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE"}]}]`,
+			expected: `[{"name":"loop","taskReferenceName":"loop_ref","type":"DO_WHILE",
+				"loopOver":[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE"},{"name":"b","taskReferenceName":"b_ref"}]}]`,
+		},
+		{
+			name: "inline sub workflow definition",
+			current: `[{"name":"sub","taskReferenceName":"sub_ref","type":"SUB_WORKFLOW","subWorkflowParam":{"name":"inline","workflowDefinition":{
+				"name":"inline","tasks":[{"name":"a","taskReferenceName":"a_ref","inputParameters":{"value":"changed"}}]}}}]`,
+			state: `[{"name":"sub","taskReferenceName":"sub_ref","type":"SUB_WORKFLOW","subWorkflowParam":{"name":"inline","workflowDefinition":{
+				"name":"inline","tasks":[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE","inputParameters":{"value":"configured"}},{"name":"b","taskReferenceName":"b_ref"}]}}}]`,
+			expected: `[{"name":"sub","taskReferenceName":"sub_ref","type":"SUB_WORKFLOW","subWorkflowParam":{"name":"inline","workflowDefinition":{
+				"name":"inline","tasks":[{"name":"a","taskReferenceName":"a_ref","type":"SIMPLE","inputParameters":{"value":"changed"}}]}}}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current := unmarshalTestJSON[[]interface{}](t, test.current)
+			state := unmarshalTestJSON[[]interface{}](t, test.state)
+			expected := unmarshalTestJSON[[]interface{}](t, test.expected)
+
+			merged := workflowTasksMerge(context.Background(), current, state)
+			if !reflect.DeepEqual(merged, expected) {
+				mergedBytes, _ := json.Marshal(merged)
+				t.Errorf("workflowTasksMerge = %s, expected %s", mergedBytes, test.expected)
+			}
+		})
+	}
+}
+
+func TestWorkflowDefMergeWithoutStateTasks(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+	}{
+		{name: "missing", state: `{"name":"wf"}`},
+		{name: "not a slice", state: `{"name":"wf","tasks":"invalid"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current := unmarshalTestJSON[map[string]interface{}](t, `{"name":"wf","tasks":[{"name":"a","taskReferenceName":"a_ref"}]}`)
+			state := unmarshalTestJSON[map[string]interface{}](t, test.state)
+
+			workflowDefMerge(context.Background(), current, state)
+
+			expected := unmarshalTestJSON[map[string]interface{}](t, `{"name":"wf","tasks":[{"name":"a","taskReferenceName":"a_ref"}]}`)
+			if !reflect.DeepEqual(state, expected) {
+				stateBytes, _ := json.Marshal(state)
+				t.Errorf("merged state = %s, expected the current tasks", stateBytes)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/dorzeidman/conductor-terraform-provider/internal/conductorapi"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	tfdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	tfdsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ tfdatasource.DataSource = &WorkflowDefVersionsDataSource{}
+var _ tfdatasource.DataSourceWithConfigure = &WorkflowDefVersionsDataSource{}
+
+type WorkflowDefVersionsDataSource struct {
+	client *conductorapi.Client
+}
+
+type WorkflowDefVersionsDataSourceModel struct {
+	Name             tftypes.String `tfsdk:"name"`
+	IncludeManifests tftypes.Bool   `tfsdk:"include_manifests"`
+	LatestVersion    tftypes.Int32  `tfsdk:"latest_version"`
+	Versions         tftypes.List   `tfsdk:"versions"`
+}
+
+type workflowDefVersionModel struct {
+	Version    tftypes.Int32        `tfsdk:"version"`
+	CreateTime tftypes.Int64        `tfsdk:"create_time"`
+	UpdateTime tftypes.Int64        `tfsdk:"update_time"`
+	Manifest   jsontypes.Normalized `tfsdk:"manifest"`
+}
+
+var workflowDefVersionAttrTypes = map[string]attr.Type{
+	"version":     tftypes.Int32Type,
+	"create_time": tftypes.Int64Type,
+	"update_time": tftypes.Int64Type,
+	"manifest":    jsontypes.NormalizedType{},
+}
+
+func NewWorkflowDefVersionsDataSource() tfdatasource.DataSource {
+	return &WorkflowDefVersionsDataSource{}
+}
+
+func (d *WorkflowDefVersionsDataSource) Metadata(ctx context.Context, req tfdatasource.MetadataRequest, resp *tfdatasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflowdef_versions"
+}
+
+func (d *WorkflowDefVersionsDataSource) Schema(ctx context.Context, req tfdatasource.SchemaRequest, resp *tfdatasource.SchemaResponse) {
+	resp.Schema = tfdsschema.Schema{
+		Description:         "All the versions of a Conductor Workflow Definition",
+		MarkdownDescription: "All the versions of a Conductor Workflow Definition, sorted by version. The list is empty when the workflow doesn't exist",
+		Attributes: map[string]tfdsschema.Attribute{
+			"name": tfdsschema.StringAttribute{
+				Description: "The workflow definition name",
+				Required:    true,
+			},
+			"include_manifests": tfdsschema.BoolAttribute{
+				Description: "Fetch the JSON Manifest of every version, one request per version. Defaults to false",
+				Optional:    true,
+			},
+			"latest_version": tfdsschema.Int32Attribute{
+				Description: "The latest version, null when the workflow doesn't exist",
+				Computed:    true,
+			},
+			"versions": tfdsschema.ListNestedAttribute{
+				Description: "The workflow definition versions",
+				Computed:    true,
+				NestedObject: tfdsschema.NestedAttributeObject{
+					Attributes: map[string]tfdsschema.Attribute{
+						"version": tfdsschema.Int32Attribute{
+							Computed: true,
+						},
+						"create_time": tfdsschema.Int64Attribute{
+							Description: "Creation time in epoch milliseconds",
+							Computed:    true,
+						},
+						"update_time": tfdsschema.Int64Attribute{
+							Description: "Last update time in epoch milliseconds, null when the version was never updated",
+							Computed:    true,
+						},
+						"manifest": tfdsschema.StringAttribute{
+							Description: "The JSON Manifest of the version without server default values, set only when include_manifests is true",
+							Computed:    true,
+							CustomType:  jsontypes.NormalizedType{},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *WorkflowDefVersionsDataSource) Configure(ctx context.Context, req tfdatasource.ConfigureRequest, resp *tfdatasource.ConfigureResponse) {
+	if req.ProviderData == nil { // this means the provider.go Configure method hasn't been called yet, so wait longer
+		return
+	}
+	provider, ok := req.ProviderData.(*ConductorProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Could not create Conductor Provider",
+			fmt.Sprintf("Expected *ConductorProvider, got: %T", req.ProviderData),
+		)
+		return
+	}
+	d.client = provider.client
+}
+
+func (d *WorkflowDefVersionsDataSource) Read(ctx context.Context, req tfdatasource.ReadRequest, resp *tfdatasource.ReadResponse) {
+	var data WorkflowDefVersionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	//metadata/workflow returns every version with its audit fields, names-and-versions has no update time
+	workflowDefs, err := d.client.GetWorkflowDefs(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list workflow def %s versions, got error: %s", name, err))
+		return
+	}
+
+	versions := make([]conductorapi.WorkflowDef, 0)
+	for _, workflowDef := range workflowDefs {
+		if workflowDef.Name == name {
+			versions = append(versions, workflowDef)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+
+	versionModels := make([]workflowDefVersionModel, 0, len(versions))
+	for _, version := range versions {
+		versionModel := workflowDefVersionModel{
+			Version:    tftypes.Int32Value(version.Version),
+			CreateTime: epochMillisValue(version.CreateTime),
+			UpdateTime: epochMillisValue(version.UpdateTime),
+			Manifest:   jsontypes.NewNormalizedNull(),
+		}
+
+		if data.IncludeManifests.ValueBool() {
+			manifestMap, err := d.client.GetWorkflowDefVersionManifest(ctx, name, version.Version)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow def %s version %d, got error: %s",
+					name, version.Version, err))
+				return
+			}
+
+			workflowDefCleanup(ctx, manifestMap)
+
+			manifestBytes, err := json.Marshal(manifestMap)
+			if err != nil {
+				resp.Diagnostics.AddError("Manifest JSON Parse error", fmt.Sprintf("Manifest must be a valid json: %s", err))
+				return
+			}
+			versionModel.Manifest = jsontypes.NewNormalizedValue(string(manifestBytes))
+		}
+
+		versionModels = append(versionModels, versionModel)
+	}
+
+	versionsList, diags := tftypes.ListValueFrom(ctx, tftypes.ObjectType{AttrTypes: workflowDefVersionAttrTypes}, versionModels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Versions = versionsList
+	data.LatestVersion = tftypes.Int32Null()
+	if len(versions) > 0 {
+		data.LatestVersion = tftypes.Int32Value(versions[len(versions)-1].Version)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
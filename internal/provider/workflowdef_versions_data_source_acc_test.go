@@ -0,0 +1,66 @@
+//go:build acceptance
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWorkflowDefVersionsDataSource(t *testing.T) {
+	fake, providerConfig := testAccFakeServer(t)
+
+	// version 1 is stored twice, the second time is an update with an update time
+	for _, version := range []int{1, 2, 1} {
+		added := fake.AddWorkflowDef(map[string]interface{}{
+			"name":        "acc_versions_workflow",
+			"version":     float64(version),
+			"description": fmt.Sprintf("version %d", version),
+			"tasks": []interface{}{
+				map[string]interface{}{"name": "acc_task", "taskReferenceName": "acc_task_ref"},
+			},
+		})
+		if !added {
+			t.Fatalf("workflow def acc_versions_workflow version %d not added to the fake server", version)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Audit times are set without the manifests
+			{
+				Config: providerConfig + `
+data "conductor_workflowdef_versions" "test" {
+  name = "acc_versions_workflow"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.conductor_workflowdef_versions.test", "latest_version", "2"),
+					resource.TestCheckResourceAttr("data.conductor_workflowdef_versions.test", "versions.#", "2"),
+					resource.TestCheckResourceAttr("data.conductor_workflowdef_versions.test", "versions.0.version", "1"),
+					resource.TestCheckResourceAttrSet("data.conductor_workflowdef_versions.test", "versions.0.create_time"),
+					resource.TestCheckResourceAttrSet("data.conductor_workflowdef_versions.test", "versions.0.update_time"),
+					resource.TestCheckNoResourceAttr("data.conductor_workflowdef_versions.test", "versions.0.manifest"),
+					resource.TestCheckResourceAttr("data.conductor_workflowdef_versions.test", "versions.1.version", "2"),
+					resource.TestCheckResourceAttrSet("data.conductor_workflowdef_versions.test", "versions.1.create_time"),
+					resource.TestCheckNoResourceAttr("data.conductor_workflowdef_versions.test", "versions.1.update_time"),
+				),
+			},
+			// A missing workflow has no versions
+			{
+				Config: providerConfig + `
+data "conductor_workflowdef_versions" "test" {
+  name = "acc_missing_workflow"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.conductor_workflowdef_versions.test", "latest_version"),
+					resource.TestCheckResourceAttr("data.conductor_workflowdef_versions.test", "versions.#", "0"),
+				),
+			},
+		},
+	})
+}